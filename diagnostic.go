@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+)
+
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic is a problem found in a struct, e.g. a field go2sql doesn't
+// support or a relationship missing its foreign keys.
+type Diagnostic struct {
+	Pos      token.Position
+	Severity Severity
+	Table    string // struct the diagnostic is about
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	if d.Pos.IsValid() {
+		return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+}
+
+func (p *Parser) report(severity Severity, table string, pos token.Pos, format string, args ...interface{}) {
+	d := Diagnostic{
+		Severity: severity,
+		Table:    table,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if p.Fset != nil {
+		d.Pos = p.Fset.Position(pos)
+	}
+	p.Diagnostics = append(p.Diagnostics, d)
+}
+
+func (p *Parser) warnf(table string, pos token.Pos, format string, args ...interface{}) {
+	p.report(SeverityWarning, table, pos, format, args...)
+}
+
+func (p *Parser) errorf(table string, pos token.Pos, format string, args ...interface{}) {
+	p.report(SeverityError, table, pos, format, args...)
+}
+
+// TableDiagnostics returns the diagnostics about the structs of names, in
+// source order.
+func (p *Parser) TableDiagnostics(names []string) (ds []Diagnostic) {
+	for _, d := range p.Diagnostics {
+		if contains(names, d.Table) {
+			ds = append(ds, d)
+		}
+	}
+	sort.SliceStable(ds, func(i, j int) bool {
+		a, b := ds[i].Pos, ds[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	pkg, err := loadPackage("./testdata/diagnostic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(pkg.Name)
+	p.Fset = pkg.Fset
+	if err := p.Parse(pkg.Info); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, d := range p.TableDiagnostics([]string{"Language", "Note", "Draft", "Search", "Entry"}) {
+		d.Pos.Filename = filepath.Base(d.Pos.Filename)
+		got = append(got, d.String())
+	}
+	want := []string{
+		`model.go:12:2: warning: Language.Author looks like belongs-to or has-one but Language has no AuthorID and Person has no LanguageID, the field is ignored`,
+		`model.go:13:2: warning: Language.Keywords looks like has-many but Keyword has no LanguageID, the field is ignored`,
+		`model.go:14:2: warning: Language.Origin: image.Point is not a struct declared in package diagnostic, the field is ignored, store it as JSON with go2sql:",json"`,
+		`model.go:15:2: error: Language.Meta: unsupported type map[string]string, skip it with go2sql:"-"`,
+		`model.go:16:2: warning: Language.Tags: unknown go2sql option "primary_key"`,
+		`model.go:16:2: error: Language.Tags: unsupported type [][]string, skip it with go2sql:"-"`,
+		`model.go:17:2: error: Language.Editor looks like belongs-to or has-one but Language has no EditorRef and Person has no EditorRef, the field is ignored`,
+		`model.go:18:2: error: Language.Readers is declared many-to-many but its type *Person is not a slice, the field is ignored`,
+		`model.go:19:2: error: Language.Owner is declared belongs-to but Language has no OwnerID, the field is ignored`,
+		`model.go:32:6: warning: Note has no primary key, mark one with go2sql:",primary-key"`,
+		`model.go:44:8: error: Draft: ambiguous column X of Point.X and Size.X, skip all but one of them with go2sql:"-"`,
+		`model.go:46:3: error: Draft.Note: embedded pointers are not supported, skip it with go2sql:"-"`,
+		`model.go:53:2: error: Search.Query: its column variable collides with the generated SearchQuery, rename the field or skip it with go2sql:"-"`,
+		`model.go:54:2: error: Search.Columns: its column variable collides with the generated SearchColumns, rename the field or skip it with go2sql:"-"`,
+		`model.go:55:2: error: Search.AllColumns: its column variable collides with the generated SearchAllColumns, rename the field or skip it with go2sql:"-"`,
+		`model.go:57:2: error: Search.ColumnName: its column variable collides with the generated SearchColumnName, rename the field or skip it with go2sql:"-"`,
+		`model.go:64:2: error: Entry.Level: the package github.com/bom-d-van/go2sql/testdata/diagnostic/log of its type has the same name as log, which the generated code of Entry imports too`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diagnostics =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n"
+	b := "1\n2\n3\nfour\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n17\n"
+	want := `--- a
++++ b
+@@ -1,7 +1,7 @@
+ 1
+ 2
+ 3
+-4
++four
+ 5
+ 6
+ 7
+@@ -14,3 +14,4 @@
+ 14
+ 15
+ 16
++17
+`
+	if got := unifiedDiff("a", "b", []byte(a), []byte(b)); got != want {
+		t.Errorf("unifiedDiff =\n%s\nwant\n%s", got, want)
+	}
+	if got := unifiedDiff("a", "b", []byte(a), []byte(a)); got != "" {
+		t.Errorf("unifiedDiff of equal files = %q; want empty", got)
+	}
+	if got, want := unifiedDiff("a", "b", nil, []byte("1\n")), "--- a\n+++ b\n@@ -0,0 +1 @@\n+1\n"; got != want {
+		t.Errorf("unifiedDiff of a new file =\n%s\nwant\n%s", got, want)
+	}
+}
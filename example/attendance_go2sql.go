@@ -0,0 +1,680 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash a5341cbc5cdcf2d71cc36f2716f3ec1a939799b9eb6d8a5fd1200ab127ab0802
+
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bom-d-van/go2sql/go2sql"
+)
+
+const (
+	AttendanceColumnID                  = "id"
+	AttendanceColumnDay                 = "day"
+	AttendanceColumnEnrollmentStudentID = "enrollment_student_id"
+	AttendanceColumnEnrollmentCourseID  = "enrollment_course_id"
+	AttendanceColumnEnrollment          = "enrollment"
+)
+
+var (
+	AttendanceID                  = go2sql.Column{Table: "attendances", Name: AttendanceColumnID, GoName: "ID", GoType: "uint", PrimaryKey: true}
+	AttendanceDay                 = go2sql.Column{Table: "attendances", Name: AttendanceColumnDay, GoName: "Day", GoType: "time.Time"}
+	AttendanceEnrollmentStudentID = go2sql.Column{Table: "attendances", Name: AttendanceColumnEnrollmentStudentID, GoName: "EnrollmentStudentID", GoType: "uint"}
+	AttendanceEnrollmentCourseID  = go2sql.Column{Table: "attendances", Name: AttendanceColumnEnrollmentCourseID, GoName: "EnrollmentCourseID", GoType: "uint"}
+)
+
+// AttendanceColumns is the registry of the columns of attendances, which the
+// selected and updated columns are checked against.
+var AttendanceColumns = go2sql.Columns{AttendanceID, AttendanceDay, AttendanceEnrollmentStudentID, AttendanceEnrollmentCourseID}
+
+var (
+	AttendanceAllColumns       = AttendanceColumns.Names()
+	AttendanceAllRelatedTables = []string{AttendanceColumnEnrollment}
+)
+
+type Attendances []*Attendance
+
+// go2sqlFields returns the fields of the columns, which are looked up in
+// AttendanceColumns, to scan into or pass as arguments.
+func (a *Attendance) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&a.ID, &a.Day, &a.EnrollmentStudentID, &a.EnrollmentCourseID}
+	for _, column := range columns {
+		i, ok := AttendanceColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
+	return
+}
+
+func (a *Attendance) IsEmptyRow() bool {
+	if a == nil {
+		return true
+	}
+
+	return a.ID == 0 &&
+		a.Day.IsZero() &&
+		a.EnrollmentStudentID == 0 &&
+		a.EnrollmentCourseID == 0 &&
+		a.Enrollment.IsEmptyRow()
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (a *Attendance) IsNewRow() bool {
+	if a == nil {
+		return true
+	}
+
+	return a.ID == 0
+}
+
+func FindAttendance(optsx ...go2sql.QueryOption) (a *Attendance, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := AttendanceAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	a = &Attendance{}
+	fields, err := a.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `attendances`.`%s` FROM `attendances` %s", strings.Join(columns, "`, `attendances`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "attendances", "find", query.SQL)
+		return
+	}
+
+	if tables, ok := opts.GetTables(); ok {
+		for _, table := range tables {
+			switch table.Name {
+			case AttendanceColumnEnrollment:
+				err = a.FetchEnrollment(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func FindAttendances(optsx ...go2sql.QueryOption) (as Attendances, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := AttendanceAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	if _, err = (&Attendance{}).go2sqlFields(columns, dialect); err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `attendances`.`%s` FROM `attendances` %s", strings.Join(columns, "`, `attendances`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "attendances", "find", query.SQL)
+		return
+	}
+	defer func() {
+		if er := rows.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "attendances", "find", query.SQL)
+			}
+		}
+	}()
+
+	for rows.Next() {
+		var a Attendance
+		fields, _ := a.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "attendances", "find", query.SQL)
+			return
+		}
+		as = append(as, &a)
+	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "attendances", "find", query.SQL)
+		return
+	}
+
+	if tables, ok := opts.GetTables(); ok {
+		for _, table := range tables {
+			switch table.Name {
+			case AttendanceColumnEnrollment:
+				err = as.FetchEnrollment(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (a *Attendance) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if !a.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return a.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case AttendanceColumnEnrollment:
+			if a.Enrollment.IsEmptyRow() {
+				continue
+			}
+			if err = a.Enrollment.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			a.EnrollmentStudentID = a.Enrollment.StudentID
+			a.EnrollmentCourseID = a.Enrollment.CourseID
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	id, err := go2sql.Insert(ctx, db, dialect, "INSERT INTO `attendances` (`day`, `enrollment_student_id`, `enrollment_course_id`) VALUES (?, ?, ?)", "id", a.Day, a.EnrollmentStudentID, a.EnrollmentCourseID)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "attendances", "insert", "INSERT INTO `attendances` (`day`, `enrollment_student_id`, `enrollment_course_id`) VALUES (?, ?, ?)")
+		return
+	}
+	a.ID = uint(id)
+
+	return
+}
+
+func (as *Attendances) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*as) == 0 {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return as.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case AttendanceColumnEnrollment:
+			var enrollments Enrollments
+			for _, a := range *as {
+				if a.IsNewRow() && !a.Enrollment.IsEmptyRow() {
+					enrollments = append(enrollments, a.Enrollment)
+				}
+			}
+			if err = enrollments.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			for _, a := range *as {
+				if a.IsNewRow() && !a.Enrollment.IsEmptyRow() {
+					a.EnrollmentStudentID = a.Enrollment.StudentID
+					a.EnrollmentCourseID = a.Enrollment.CourseID
+				}
+			}
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `attendances` (`day`, `enrollment_student_id`, `enrollment_course_id`) VALUES (?, ?, ?)", "id")
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "attendances", "insert", "INSERT INTO `attendances` (`day`, `enrollment_student_id`, `enrollment_course_id`) VALUES (?, ?, ?)")
+		return
+	}
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "attendances", "insert", "INSERT INTO `attendances` (`day`, `enrollment_student_id`, `enrollment_course_id`) VALUES (?, ?, ?)")
+			}
+		}
+	}()
+	for _, a := range *as {
+		if !a.IsNewRow() {
+			continue
+		}
+		id, err := stmt.Exec(ctx, a.Day, a.EnrollmentStudentID, a.EnrollmentCourseID)
+		if err != nil {
+			return go2sql.WrapError(err, dialect, "attendances", "insert", "INSERT INTO `attendances` (`day`, `enrollment_student_id`, `enrollment_course_id`) VALUES (?, ?, ?)")
+		}
+		a.ID = uint(id)
+	}
+
+	return
+}
+
+// Update inserts the row if it's new, or updates all its columns otherwise.
+func (a *Attendance) Update(optsx ...go2sql.UpdateOption) (err error) {
+	if a == nil {
+		return
+	}
+
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return a.Update(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case AttendanceColumnEnrollment:
+			if a.Enrollment.IsEmptyRow() {
+				continue
+			}
+			if err = a.Enrollment.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			a.EnrollmentStudentID = a.Enrollment.StudentID
+			a.EnrollmentCourseID = a.Enrollment.CourseID
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	if a.IsNewRow() {
+		err = a.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+	} else {
+		_, err = go2sql.Exec(ctx, db, dialect, "attendances", "update", "UPDATE `attendances` SET `day` = ?, `enrollment_student_id` = ?, `enrollment_course_id` = ? WHERE `id` = ?", a.Day, a.EnrollmentStudentID, a.EnrollmentCourseID, a.ID)
+	}
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (a *Attendance) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if a.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
+	}
+
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
+	}
+	args, err := a.go2sqlFields(sel, dialect)
+	if err != nil {
+		return
+	}
+	var updates []string
+	for _, column := range sel {
+		if c, _ := AttendanceColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
+			return
+		}
+		updates = append(updates, "`"+column+"` = ?")
+	}
+
+	args = append(args, a.ID)
+	_, err = go2sql.Exec(ctx, db, dialect, "attendances", "update", fmt.Sprintf("UPDATE `attendances` SET %s WHERE `id` = ?", strings.Join(updates, ", ")), args...)
+	return
+}
+
+func (as *Attendances) Update(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return as.Update(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	optsx = append(optsx, go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+
+	for _, a := range *as {
+		if err = a.Update(optsx...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (a *Attendance) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	if a.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return a.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case AttendanceColumnEnrollment:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "attendances", "delete", "DELETE FROM `attendances` WHERE `id` = ?", a.ID); err != nil {
+		return
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case AttendanceColumnEnrollment:
+			if err = a.Enrollment.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (as *Attendances) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, a := range *as {
+		if !a.IsNewRow() {
+			keys = append(keys, a.ID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return as.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case AttendanceColumnEnrollment:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `attendances` WHERE `id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "attendances", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	if table, ok := tables.Get(AttendanceColumnEnrollment); ok {
+		var enrollments Enrollments
+		for _, a := range *as {
+			if !a.Enrollment.IsEmptyRow() {
+				enrollments = append(enrollments, a.Enrollment)
+			}
+		}
+		if err = enrollments.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (a *Attendance) FetchEnrollment(optsx ...go2sql.QueryOption) error {
+	as := Attendances{a}
+	return as.FetchEnrollment(optsx...)
+}
+
+func (as *Attendances) FetchEnrollment(optsx ...go2sql.QueryOption) (err error) {
+	var keys []interface{}
+	for _, a := range *as {
+		a.Enrollment = nil
+		keys = append(keys, []interface{}{a.EnrollmentStudentID, a.EnrollmentCourseID})
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
+	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		enrollments, err := FindEnrollments(append(guestOpts, go2sql.NewSQL("WHERE (`student_id`, `course_id`) IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range *as {
+			for _, enrollment := range enrollments {
+				if a.EnrollmentStudentID == enrollment.StudentID && a.EnrollmentCourseID == enrollment.CourseID {
+					a.Enrollment = enrollment
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// AttendanceQueryBuilder composes the queries of attendances, see AttendanceQuery.
+type AttendanceQueryBuilder struct {
+	query go2sql.Query
+}
+
+// AttendanceQuery starts a query of attendances, e.g.
+// AttendanceQuery().Where(cond).OrderBy(order).Limit(10).All().
+func AttendanceQuery() *AttendanceQueryBuilder {
+	return &AttendanceQueryBuilder{}
+}
+
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *AttendanceQueryBuilder) Where(conds ...go2sql.SQL) *AttendanceQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *AttendanceQueryBuilder) Join(join string, args ...interface{}) *AttendanceQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *AttendanceQueryBuilder) OrderBy(orders ...go2sql.SQL) *AttendanceQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *AttendanceQueryBuilder) Limit(limit int) *AttendanceQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *AttendanceQueryBuilder) Offset(offset int) *AttendanceQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *AttendanceQueryBuilder) With(opts ...go2sql.QueryOption) *AttendanceQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *AttendanceQueryBuilder) All() (Attendances, error) {
+	opts, err := q.query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindAttendances(opts...)
+}
+
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *AttendanceQueryBuilder) One() (*Attendance, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindAttendance(opts...)
+}
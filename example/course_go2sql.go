@@ -0,0 +1,446 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash cedf6ce08d9725e6bcf8613d4b9f81767e3d6b4dd237b79612b1d3e74c4f5f9e
+
+package model
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bom-d-van/go2sql/go2sql"
+)
+
+const (
+	CourseColumnID    = "id"
+	CourseColumnTitle = "title"
+)
+
+var (
+	CourseID    = go2sql.Column{Table: "courses", Name: CourseColumnID, GoName: "ID", GoType: "uint", PrimaryKey: true}
+	CourseTitle = go2sql.Column{Table: "courses", Name: CourseColumnTitle, GoName: "Title", GoType: "string"}
+)
+
+// CourseColumns is the registry of the columns of courses, which the
+// selected and updated columns are checked against.
+var CourseColumns = go2sql.Columns{CourseID, CourseTitle}
+
+var (
+	CourseAllColumns       = CourseColumns.Names()
+	CourseAllRelatedTables = []string{}
+)
+
+type Courses []*Course
+
+// go2sqlFields returns the fields of the columns, which are looked up in
+// CourseColumns, to scan into or pass as arguments.
+func (c *Course) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&c.ID, &c.Title}
+	for _, column := range columns {
+		i, ok := CourseColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
+	return
+}
+
+func (c *Course) IsEmptyRow() bool {
+	if c == nil {
+		return true
+	}
+
+	return c.ID == 0 &&
+		c.Title == ""
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (c *Course) IsNewRow() bool {
+	if c == nil {
+		return true
+	}
+
+	return c.ID == 0
+}
+
+func FindCourse(optsx ...go2sql.QueryOption) (c *Course, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := CourseAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	c = &Course{}
+	fields, err := c.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `courses`.`%s` FROM `courses` %s", strings.Join(columns, "`, `courses`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "courses", "find", query.SQL)
+		return
+	}
+
+	return
+}
+
+func FindCourses(optsx ...go2sql.QueryOption) (cs Courses, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := CourseAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	if _, err = (&Course{}).go2sqlFields(columns, dialect); err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `courses`.`%s` FROM `courses` %s", strings.Join(columns, "`, `courses`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "courses", "find", query.SQL)
+		return
+	}
+	defer func() {
+		if er := rows.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "courses", "find", query.SQL)
+			}
+		}
+	}()
+
+	for rows.Next() {
+		var c Course
+		fields, _ := c.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "courses", "find", query.SQL)
+			return
+		}
+		cs = append(cs, &c)
+	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "courses", "find", query.SQL)
+		return
+	}
+
+	return
+}
+
+func (c *Course) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if !c.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	id, err := go2sql.Insert(ctx, db, dialect, "INSERT INTO `courses` (`title`) VALUES (?)", "id", c.Title)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "courses", "insert", "INSERT INTO `courses` (`title`) VALUES (?)")
+		return
+	}
+	c.ID = uint(id)
+
+	return
+}
+
+func (cs *Courses) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*cs) == 0 {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `courses` (`title`) VALUES (?)", "id")
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "courses", "insert", "INSERT INTO `courses` (`title`) VALUES (?)")
+		return
+	}
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "courses", "insert", "INSERT INTO `courses` (`title`) VALUES (?)")
+			}
+		}
+	}()
+	for _, c := range *cs {
+		if !c.IsNewRow() {
+			continue
+		}
+		id, err := stmt.Exec(ctx, c.Title)
+		if err != nil {
+			return go2sql.WrapError(err, dialect, "courses", "insert", "INSERT INTO `courses` (`title`) VALUES (?)")
+		}
+		c.ID = uint(id)
+	}
+
+	return
+}
+
+// Update inserts the row if it's new, or updates all its columns otherwise.
+func (c *Course) Update(optsx ...go2sql.UpdateOption) (err error) {
+	if c == nil {
+		return
+	}
+
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if c.IsNewRow() {
+		err = c.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+	} else {
+		_, err = go2sql.Exec(ctx, db, dialect, "courses", "update", "UPDATE `courses` SET `title` = ? WHERE `id` = ?", c.Title, c.ID)
+	}
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (c *Course) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if c.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
+	}
+
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
+	}
+	args, err := c.go2sqlFields(sel, dialect)
+	if err != nil {
+		return
+	}
+	var updates []string
+	for _, column := range sel {
+		if c, _ := CourseColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
+			return
+		}
+		updates = append(updates, "`"+column+"` = ?")
+	}
+
+	args = append(args, c.ID)
+	_, err = go2sql.Exec(ctx, db, dialect, "courses", "update", fmt.Sprintf("UPDATE `courses` SET %s WHERE `id` = ?", strings.Join(updates, ", ")), args...)
+	return
+}
+
+func (cs *Courses) Update(optsx ...go2sql.UpdateOption) (err error) {
+
+	for _, c := range *cs {
+		if err = c.Update(optsx...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c *Course) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	if c.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "courses", "delete", "DELETE FROM `courses` WHERE `id` = ?", c.ID); err != nil {
+		return
+	}
+
+	return
+}
+
+func (cs *Courses) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, c := range *cs {
+		if !c.IsNewRow() {
+			keys = append(keys, c.ID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `courses` WHERE `id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "courses", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// CourseQueryBuilder composes the queries of courses, see CourseQuery.
+type CourseQueryBuilder struct {
+	query go2sql.Query
+}
+
+// CourseQuery starts a query of courses, e.g.
+// CourseQuery().Where(cond).OrderBy(order).Limit(10).All().
+func CourseQuery() *CourseQueryBuilder {
+	return &CourseQueryBuilder{}
+}
+
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *CourseQueryBuilder) Where(conds ...go2sql.SQL) *CourseQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *CourseQueryBuilder) Join(join string, args ...interface{}) *CourseQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *CourseQueryBuilder) OrderBy(orders ...go2sql.SQL) *CourseQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *CourseQueryBuilder) Limit(limit int) *CourseQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *CourseQueryBuilder) Offset(offset int) *CourseQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *CourseQueryBuilder) With(opts ...go2sql.QueryOption) *CourseQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *CourseQueryBuilder) All() (Courses, error) {
+	opts, err := q.query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindCourses(opts...)
+}
+
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *CourseQueryBuilder) One() (*Course, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindCourse(opts...)
+}
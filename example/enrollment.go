@@ -0,0 +1,35 @@
+package model
+
+//go:generate go2sql Enrollment Student Course Attendance
+
+import "time"
+
+// Enrollment is keyed by StudentID and CourseID, which are not generated by
+// the database, and Attendance refers to it by both of them.
+type Enrollment struct {
+	StudentID   uint `go2sql:",primary-key"`
+	CourseID    uint `go2sql:",primary-key"`
+	Grade       string
+	Student     *Student
+	Course      *Course
+	Attendances []*Attendance
+}
+
+type Student struct {
+	ID          uint `go2sql:",id,primary-key"`
+	Name        string
+	Enrollments []*Enrollment
+}
+
+type Course struct {
+	ID    uint `go2sql:",id,primary-key"`
+	Title string
+}
+
+type Attendance struct {
+	ID                  uint `go2sql:",id,primary-key"`
+	Day                 time.Time
+	EnrollmentStudentID uint
+	EnrollmentCourseID  uint
+	Enrollment          *Enrollment
+}
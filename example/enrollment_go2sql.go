@@ -0,0 +1,880 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash 792d713ad6fdbee91a8143a6d2013b474e59d542d25d39044ea794692b3bd9f7
+
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bom-d-van/go2sql/go2sql"
+)
+
+const (
+	EnrollmentColumnStudentID   = "student_id"
+	EnrollmentColumnCourseID    = "course_id"
+	EnrollmentColumnGrade       = "grade"
+	EnrollmentColumnStudent     = "student"
+	EnrollmentColumnCourse      = "course"
+	EnrollmentColumnAttendances = "attendances"
+)
+
+var (
+	EnrollmentStudentID = go2sql.Column{Table: "enrollments", Name: EnrollmentColumnStudentID, GoName: "StudentID", GoType: "uint", PrimaryKey: true}
+	EnrollmentCourseID  = go2sql.Column{Table: "enrollments", Name: EnrollmentColumnCourseID, GoName: "CourseID", GoType: "uint", PrimaryKey: true}
+	EnrollmentGrade     = go2sql.Column{Table: "enrollments", Name: EnrollmentColumnGrade, GoName: "Grade", GoType: "string"}
+)
+
+// EnrollmentColumns is the registry of the columns of enrollments, which the
+// selected and updated columns are checked against.
+var EnrollmentColumns = go2sql.Columns{EnrollmentStudentID, EnrollmentCourseID, EnrollmentGrade}
+
+var (
+	EnrollmentAllColumns       = EnrollmentColumns.Names()
+	EnrollmentAllRelatedTables = []string{EnrollmentColumnStudent, EnrollmentColumnCourse, EnrollmentColumnAttendances}
+)
+
+type Enrollments []*Enrollment
+
+// go2sqlFields returns the fields of the columns, which are looked up in
+// EnrollmentColumns, to scan into or pass as arguments.
+func (e *Enrollment) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&e.StudentID, &e.CourseID, &e.Grade}
+	for _, column := range columns {
+		i, ok := EnrollmentColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
+	return
+}
+
+func (e *Enrollment) IsEmptyRow() bool {
+	if e == nil {
+		return true
+	}
+
+	return e.StudentID == 0 &&
+		e.CourseID == 0 &&
+		e.Grade == "" &&
+		e.Student.IsEmptyRow() &&
+		e.Course.IsEmptyRow() &&
+		len(e.Attendances) == 0
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (e *Enrollment) IsNewRow() bool {
+	if e == nil {
+		return true
+	}
+
+	return e.StudentID == 0 &&
+		e.CourseID == 0
+}
+
+func FindEnrollment(optsx ...go2sql.QueryOption) (e *Enrollment, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := EnrollmentAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	e = &Enrollment{}
+	fields, err := e.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `enrollments`.`%s` FROM `enrollments` %s", strings.Join(columns, "`, `enrollments`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "enrollments", "find", query.SQL)
+		return
+	}
+
+	if tables, ok := opts.GetTables(); ok {
+		for _, table := range tables {
+			switch table.Name {
+			case EnrollmentColumnStudent:
+				err = e.FetchStudent(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			case EnrollmentColumnCourse:
+				err = e.FetchCourse(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			case EnrollmentColumnAttendances:
+				err = e.FetchAttendances(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func FindEnrollments(optsx ...go2sql.QueryOption) (es Enrollments, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := EnrollmentAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	if _, err = (&Enrollment{}).go2sqlFields(columns, dialect); err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `enrollments`.`%s` FROM `enrollments` %s", strings.Join(columns, "`, `enrollments`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "enrollments", "find", query.SQL)
+		return
+	}
+	defer func() {
+		if er := rows.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "enrollments", "find", query.SQL)
+			}
+		}
+	}()
+
+	for rows.Next() {
+		var e Enrollment
+		fields, _ := e.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "enrollments", "find", query.SQL)
+			return
+		}
+		es = append(es, &e)
+	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "enrollments", "find", query.SQL)
+		return
+	}
+
+	if tables, ok := opts.GetTables(); ok {
+		for _, table := range tables {
+			switch table.Name {
+			case EnrollmentColumnStudent:
+				err = es.FetchStudent(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			case EnrollmentColumnCourse:
+				err = es.FetchCourse(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			case EnrollmentColumnAttendances:
+				err = es.FetchAttendances(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (e *Enrollment) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if e == nil {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return e.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnStudent:
+			if e.Student.IsEmptyRow() {
+				continue
+			}
+			if err = e.Student.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			e.StudentID = e.Student.ID
+		case EnrollmentColumnCourse:
+			if e.Course.IsEmptyRow() {
+				continue
+			}
+			if err = e.Course.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			e.CourseID = e.Course.ID
+		case EnrollmentColumnAttendances:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	if _, err = go2sql.Insert(ctx, db, dialect, "INSERT INTO `enrollments` (`student_id`, `course_id`, `grade`) VALUES (?, ?, ?)", "", e.StudentID, e.CourseID, e.Grade); err != nil {
+		err = go2sql.WrapError(err, dialect, "enrollments", "insert", "INSERT INTO `enrollments` (`student_id`, `course_id`, `grade`) VALUES (?, ?, ?)")
+		return
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnAttendances:
+			var attendances Attendances
+			for i := range e.Attendances {
+				attendance := e.Attendances[i]
+				attendance.EnrollmentStudentID = e.StudentID
+				attendance.EnrollmentCourseID = e.CourseID
+				attendances = append(attendances, attendance)
+			}
+			if err = attendances.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (es *Enrollments) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*es) == 0 {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return es.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnStudent:
+			var students Students
+			for _, e := range *es {
+				if !e.Student.IsEmptyRow() {
+					students = append(students, e.Student)
+				}
+			}
+			if err = students.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			for _, e := range *es {
+				if !e.Student.IsEmptyRow() {
+					e.StudentID = e.Student.ID
+				}
+			}
+		case EnrollmentColumnCourse:
+			var courses Courses
+			for _, e := range *es {
+				if !e.Course.IsEmptyRow() {
+					courses = append(courses, e.Course)
+				}
+			}
+			if err = courses.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			for _, e := range *es {
+				if !e.Course.IsEmptyRow() {
+					e.CourseID = e.Course.ID
+				}
+			}
+		case EnrollmentColumnAttendances:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `enrollments` (`student_id`, `course_id`, `grade`) VALUES (?, ?, ?)", "")
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "enrollments", "insert", "INSERT INTO `enrollments` (`student_id`, `course_id`, `grade`) VALUES (?, ?, ?)")
+		return
+	}
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "enrollments", "insert", "INSERT INTO `enrollments` (`student_id`, `course_id`, `grade`) VALUES (?, ?, ?)")
+			}
+		}
+	}()
+	for _, e := range *es {
+		if e == nil {
+			continue
+		}
+		if _, err = stmt.Exec(ctx, e.StudentID, e.CourseID, e.Grade); err != nil {
+			err = go2sql.WrapError(err, dialect, "enrollments", "insert", "INSERT INTO `enrollments` (`student_id`, `course_id`, `grade`) VALUES (?, ?, ?)")
+			return
+		}
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnAttendances:
+			var attendances Attendances
+			for _, e := range *es {
+				for i := range e.Attendances {
+					attendance := e.Attendances[i]
+					attendance.EnrollmentStudentID = e.StudentID
+					attendance.EnrollmentCourseID = e.CourseID
+					attendances = append(attendances, attendance)
+				}
+			}
+			if err = attendances.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// Update inserts the row, or updates all its columns if its primary keys
+// exist.
+func (e *Enrollment) Update(optsx ...go2sql.UpdateOption) (err error) {
+	if e == nil {
+		return
+	}
+
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return e.Update(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnStudent:
+			if e.Student.IsEmptyRow() {
+				continue
+			}
+			if err = e.Student.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			e.StudentID = e.Student.ID
+		case EnrollmentColumnCourse:
+			if e.Course.IsEmptyRow() {
+				continue
+			}
+			if err = e.Course.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			e.CourseID = e.Course.ID
+		case EnrollmentColumnAttendances:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	query := "INSERT INTO `enrollments` (`student_id`, `course_id`, `grade`) VALUES (?, ?, ?) " + dialect.Upsert([]string{"student_id", "course_id"}, []string{"grade"})
+	if _, err = go2sql.Insert(ctx, db, dialect, query, "", e.StudentID, e.CourseID, e.Grade); err != nil {
+		err = go2sql.WrapError(err, dialect, "enrollments", "update", query)
+	}
+	if err != nil {
+		return
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnAttendances:
+			var attendances Attendances
+			for i := range e.Attendances {
+				attendance := e.Attendances[i]
+				attendance.EnrollmentStudentID = e.StudentID
+				attendance.EnrollmentCourseID = e.CourseID
+				attendances = append(attendances, attendance)
+			}
+			if err = attendances.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (e *Enrollment) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if e.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
+	}
+
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
+	}
+	args, err := e.go2sqlFields(sel, dialect)
+	if err != nil {
+		return
+	}
+	var updates []string
+	for _, column := range sel {
+		if c, _ := EnrollmentColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
+			return
+		}
+		updates = append(updates, "`"+column+"` = ?")
+	}
+
+	args = append(args, e.StudentID, e.CourseID)
+	_, err = go2sql.Exec(ctx, db, dialect, "enrollments", "update", fmt.Sprintf("UPDATE `enrollments` SET %s WHERE (`student_id` = ? and `course_id` = ?)", strings.Join(updates, ", ")), args...)
+	return
+}
+
+func (es *Enrollments) Update(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return es.Update(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	optsx = append(optsx, go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+
+	for _, e := range *es {
+		if err = e.Update(optsx...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (e *Enrollment) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	if e.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return e.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnAttendances:
+			var attendances Attendances
+			for i := range e.Attendances {
+				attendances = append(attendances, e.Attendances[i])
+			}
+			err = attendances.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+		case EnrollmentColumnStudent, EnrollmentColumnCourse:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "enrollments", "delete", "DELETE FROM `enrollments` WHERE (`student_id` = ? and `course_id` = ?)", e.StudentID, e.CourseID); err != nil {
+		return
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnStudent:
+			if err = e.Student.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		case EnrollmentColumnCourse:
+			if err = e.Course.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (es *Enrollments) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, e := range *es {
+		if !e.IsNewRow() {
+			keys = append(keys, []interface{}{e.StudentID, e.CourseID})
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return es.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case EnrollmentColumnAttendances:
+			var attendances Attendances
+			for _, e := range *es {
+				for i := range e.Attendances {
+					attendances = append(attendances, e.Attendances[i])
+				}
+			}
+			err = attendances.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+		case EnrollmentColumnStudent, EnrollmentColumnCourse:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `enrollments` WHERE (`student_id`, `course_id`) IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "enrollments", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	if table, ok := tables.Get(EnrollmentColumnStudent); ok {
+		var students Students
+		for _, e := range *es {
+			if !e.Student.IsEmptyRow() {
+				students = append(students, e.Student)
+			}
+		}
+		if err = students.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+			return
+		}
+	}
+
+	if table, ok := tables.Get(EnrollmentColumnCourse); ok {
+		var courses Courses
+		for _, e := range *es {
+			if !e.Course.IsEmptyRow() {
+				courses = append(courses, e.Course)
+			}
+		}
+		if err = courses.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (e *Enrollment) FetchStudent(optsx ...go2sql.QueryOption) error {
+	es := Enrollments{e}
+	return es.FetchStudent(optsx...)
+}
+
+func (es *Enrollments) FetchStudent(optsx ...go2sql.QueryOption) (err error) {
+	var keys []interface{}
+	for _, e := range *es {
+		e.Student = nil
+		keys = append(keys, e.StudentID)
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
+	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		students, err := FindStudents(append(guestOpts, go2sql.NewSQL("WHERE `id` IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range *es {
+			for _, student := range students {
+				if e.StudentID == student.ID {
+					e.Student = student
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func (e *Enrollment) FetchCourse(optsx ...go2sql.QueryOption) error {
+	es := Enrollments{e}
+	return es.FetchCourse(optsx...)
+}
+
+func (es *Enrollments) FetchCourse(optsx ...go2sql.QueryOption) (err error) {
+	var keys []interface{}
+	for _, e := range *es {
+		e.Course = nil
+		keys = append(keys, e.CourseID)
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
+	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		courses, err := FindCourses(append(guestOpts, go2sql.NewSQL("WHERE `id` IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range *es {
+			for _, course := range courses {
+				if e.CourseID == course.ID {
+					e.Course = course
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func (e *Enrollment) FetchAttendances(optsx ...go2sql.QueryOption) error {
+	es := Enrollments{e}
+	return es.FetchAttendances(optsx...)
+}
+
+func (es *Enrollments) FetchAttendances(optsx ...go2sql.QueryOption) (err error) {
+	var keys []interface{}
+	for _, e := range *es {
+		e.Attendances = nil
+		keys = append(keys, []interface{}{e.StudentID, e.CourseID})
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
+	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		attendances, err := FindAttendances(append(guestOpts, go2sql.NewSQL("WHERE (`enrollment_student_id`, `enrollment_course_id`) IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range *es {
+			for _, attendance := range attendances {
+				if e.StudentID == attendance.EnrollmentStudentID && e.CourseID == attendance.EnrollmentCourseID {
+					e.Attendances = append(e.Attendances, attendance)
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// EnrollmentQueryBuilder composes the queries of enrollments, see EnrollmentQuery.
+type EnrollmentQueryBuilder struct {
+	query go2sql.Query
+}
+
+// EnrollmentQuery starts a query of enrollments, e.g.
+// EnrollmentQuery().Where(cond).OrderBy(order).Limit(10).All().
+func EnrollmentQuery() *EnrollmentQueryBuilder {
+	return &EnrollmentQueryBuilder{}
+}
+
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *EnrollmentQueryBuilder) Where(conds ...go2sql.SQL) *EnrollmentQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *EnrollmentQueryBuilder) Join(join string, args ...interface{}) *EnrollmentQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *EnrollmentQueryBuilder) OrderBy(orders ...go2sql.SQL) *EnrollmentQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *EnrollmentQueryBuilder) Limit(limit int) *EnrollmentQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *EnrollmentQueryBuilder) Offset(offset int) *EnrollmentQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *EnrollmentQueryBuilder) With(opts ...go2sql.QueryOption) *EnrollmentQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *EnrollmentQueryBuilder) All() (Enrollments, error) {
+	opts, err := q.query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindEnrollments(opts...)
+}
+
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *EnrollmentQueryBuilder) One() (*Enrollment, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindEnrollment(opts...)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func main() {
-	db, err := sql.Open("mysql", "root:@/go2sql_example")
+	db, err := sql.Open("mysql", "root:@/go2sql_example?parseTime=true")
 	if err != nil {
 		panic(err)
 	}
@@ -32,6 +32,16 @@ func main() {
 			field5 varchar(255) not null default 'text',
 			field6 varchar(255) not null default 'text',
 			field7 varchar(255) not null default 'text',
+			created_at DATETIME,
+			description TEXT,
+			origin_created_at DATETIME,
+			origin_description TEXT,
+			author_id int DEFAULT NULL,
+			embed JSON,
+			my_string TEXT,
+			aliases TEXT,
+			html TEXT,
+			teacher_id int NOT NULL DEFAULT 0,
 			PRIMARY KEY (id)
 		);
 	`))
@@ -1,68 +1,452 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash f92b7f7fed47db71507655bec847908438a6d71d360bb6527c90cbdc478f93c7
+
 package model
 
 import (
+	"fmt"
+	"log"
+	"strings"
+
 	"github.com/bom-d-van/go2sql/go2sql"
+)
 
-	"database/sql"
+const (
+	KeywordColumnID         = "id"
+	KeywordColumnName       = "name"
+	KeywordColumnType       = "type"
+	KeywordColumnLanguageID = "language_id"
+)
+
+var (
+	KeywordID         = go2sql.Column{Table: "keywords", Name: KeywordColumnID, GoName: "ID", GoType: "uint", PrimaryKey: true}
+	KeywordName       = go2sql.Column{Table: "keywords", Name: KeywordColumnName, GoName: "Name", GoType: "string"}
+	KeywordType       = go2sql.Column{Table: "keywords", Name: KeywordColumnType, GoName: "Type", GoType: "string"}
+	KeywordLanguageID = go2sql.Column{Table: "keywords", Name: KeywordColumnLanguageID, GoName: "LanguageID", GoType: "uint"}
+)
+
+// KeywordColumns is the registry of the columns of keywords, which the
+// selected and updated columns are checked against.
+var KeywordColumns = go2sql.Columns{KeywordID, KeywordName, KeywordType, KeywordLanguageID}
+
+var (
+	KeywordAllColumns       = KeywordColumns.Names()
+	KeywordAllRelatedTables = []string{}
 )
 
 type Keywords []*Keyword
 
-func (t *Keywords) IsEmptyRow() (is bool)                           { return }
-func (t *Keywords) IsNewRow() (is bool)                             { return }
-func (t *Keywords) Insert(optsx ...go2sql.InsertOption) (err error) { return }
-func (t *Keywords) Update(optsx ...go2sql.UpdateOption) (err error) { return }
-func (t *Keywords) Delete(optsx ...go2sql.DeleteOption) (err error) { return }
-
-func (k *Keyword) Insert(optsx ...go2sql.InsertOption) (r sql.Result, err error) {
-	// re, err := db.Exec(`INSERT INTO keywords (name, type, language_id) VALUES(?, ?, ?)`, k.Name, k.Type, l.ID)
-	// if err != nil {
-	// 	return r, err
-	// }
-
-	// var id int64
-	// if id, err = re.LastInsertId(); err != nil {
-	// 	return r, err
-	// }
-	// l.Keywords[i].ID = uint(id)
+// go2sqlFields returns the fields of the columns, which are looked up in
+// KeywordColumns, to scan into or pass as arguments.
+func (k *Keyword) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&k.ID, &k.Name, &k.Type, &k.LanguageID}
+	for _, column := range columns {
+		i, ok := KeywordColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
 	return
 }
 
-func FindKeywords(opts ...go2sql.QueryOption) (k []*Keyword, err error) {
+func (k *Keyword) IsEmptyRow() bool {
+	if k == nil {
+		return true
+	}
+
+	return k.ID == 0 &&
+		k.Name == "" &&
+		k.Type == "" &&
+		k.LanguageID == 0
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (k *Keyword) IsNewRow() bool {
+	if k == nil {
+		return true
+	}
+
+	return k.ID == 0
+}
+
+func FindKeyword(optsx ...go2sql.QueryOption) (k *Keyword, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := KeywordAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	k = &Keyword{}
+	fields, err := k.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `keywords`.`%s` FROM `keywords` %s", strings.Join(columns, "`, `keywords`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "keywords", "find", query.SQL)
+		return
+	}
+
 	return
 }
 
-func FindKeyword(opts ...go2sql.QueryOption) (k *Keyword, err error) {
-	// rows, err := db.Query(`select id, name, type, language_id from keywords where language_id = ?`, l.ID)
-	// if err != nil {
-	// 	return
-	// }
-
-	// defer func() {
-	// 	if er := rows.Close(); er != nil {
-	// 		if err != nil {
-	// 			log.Println(er)
-	// 		} else {
-	// 			err = er
-	// 		}
-	// 	}
-	// }()
-
-	// // l.Keywords = []Keyword{}
-	// for rows.Next() {
-	// 	var k Keyword
-	// 	if err = rows.Scan(&k.ID, &k.Name, &k.Type, &k.LanguageID); err != nil {
-	// 		return
-	// 	}
-	// 	l.Keywords = append(l.Keywords, k)
-	// }
+func FindKeywords(optsx ...go2sql.QueryOption) (ks Keywords, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := KeywordAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	if _, err = (&Keyword{}).go2sqlFields(columns, dialect); err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `keywords`.`%s` FROM `keywords` %s", strings.Join(columns, "`, `keywords`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "keywords", "find", query.SQL)
+		return
+	}
+	defer func() {
+		if er := rows.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "keywords", "find", query.SQL)
+			}
+		}
+	}()
+
+	for rows.Next() {
+		var k Keyword
+		fields, _ := k.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "keywords", "find", query.SQL)
+			return
+		}
+		ks = append(ks, &k)
+	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "keywords", "find", query.SQL)
+		return
+	}
+
 	return
 }
 
-func (k *Keyword) Update(db *sql.DB) (r sql.Result, err error) { return }
-func (k *Keyword) Delete(db *sql.DB) (r sql.Result, err error) { return }
+func (k *Keyword) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if !k.IsNewRow() {
+		return
+	}
 
-func (k *Keyword) IsEmptyRow() bool {
-	empty := Keyword{}
-	return *k == empty
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	id, err := go2sql.Insert(ctx, db, dialect, "INSERT INTO `keywords` (`name`, `type`, `language_id`) VALUES (?, ?, ?)", "id", k.Name, k.Type, k.LanguageID)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "keywords", "insert", "INSERT INTO `keywords` (`name`, `type`, `language_id`) VALUES (?, ?, ?)")
+		return
+	}
+	k.ID = uint(id)
+
+	return
+}
+
+func (ks *Keywords) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*ks) == 0 {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `keywords` (`name`, `type`, `language_id`) VALUES (?, ?, ?)", "id")
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "keywords", "insert", "INSERT INTO `keywords` (`name`, `type`, `language_id`) VALUES (?, ?, ?)")
+		return
+	}
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "keywords", "insert", "INSERT INTO `keywords` (`name`, `type`, `language_id`) VALUES (?, ?, ?)")
+			}
+		}
+	}()
+	for _, k := range *ks {
+		if !k.IsNewRow() {
+			continue
+		}
+		id, err := stmt.Exec(ctx, k.Name, k.Type, k.LanguageID)
+		if err != nil {
+			return go2sql.WrapError(err, dialect, "keywords", "insert", "INSERT INTO `keywords` (`name`, `type`, `language_id`) VALUES (?, ?, ?)")
+		}
+		k.ID = uint(id)
+	}
+
+	return
+}
+
+// Update inserts the row if it's new, or updates all its columns otherwise.
+func (k *Keyword) Update(optsx ...go2sql.UpdateOption) (err error) {
+	if k == nil {
+		return
+	}
+
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if k.IsNewRow() {
+		err = k.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+	} else {
+		_, err = go2sql.Exec(ctx, db, dialect, "keywords", "update", "UPDATE `keywords` SET `name` = ?, `type` = ?, `language_id` = ? WHERE `id` = ?", k.Name, k.Type, k.LanguageID, k.ID)
+	}
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (k *Keyword) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if k.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
+	}
+
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
+	}
+	args, err := k.go2sqlFields(sel, dialect)
+	if err != nil {
+		return
+	}
+	var updates []string
+	for _, column := range sel {
+		if c, _ := KeywordColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
+			return
+		}
+		updates = append(updates, "`"+column+"` = ?")
+	}
+
+	args = append(args, k.ID)
+	_, err = go2sql.Exec(ctx, db, dialect, "keywords", "update", fmt.Sprintf("UPDATE `keywords` SET %s WHERE `id` = ?", strings.Join(updates, ", ")), args...)
+	return
+}
+
+func (ks *Keywords) Update(optsx ...go2sql.UpdateOption) (err error) {
+
+	for _, k := range *ks {
+		if err = k.Update(optsx...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (k *Keyword) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	if k.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "keywords", "delete", "DELETE FROM `keywords` WHERE `id` = ?", k.ID); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ks *Keywords) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, k := range *ks {
+		if !k.IsNewRow() {
+			keys = append(keys, k.ID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `keywords` WHERE `id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "keywords", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// KeywordQueryBuilder composes the queries of keywords, see KeywordQuery.
+type KeywordQueryBuilder struct {
+	query go2sql.Query
+}
+
+// KeywordQuery starts a query of keywords, e.g.
+// KeywordQuery().Where(cond).OrderBy(order).Limit(10).All().
+func KeywordQuery() *KeywordQueryBuilder {
+	return &KeywordQueryBuilder{}
+}
+
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *KeywordQueryBuilder) Where(conds ...go2sql.SQL) *KeywordQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *KeywordQueryBuilder) Join(join string, args ...interface{}) *KeywordQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *KeywordQueryBuilder) OrderBy(orders ...go2sql.SQL) *KeywordQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *KeywordQueryBuilder) Limit(limit int) *KeywordQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *KeywordQueryBuilder) Offset(offset int) *KeywordQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *KeywordQueryBuilder) With(opts ...go2sql.QueryOption) *KeywordQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *KeywordQueryBuilder) All() (Keywords, error) {
+	opts, err := q.query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindKeywords(opts...)
+}
+
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *KeywordQueryBuilder) One() (*Keyword, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindKeyword(opts...)
 }
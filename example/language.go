@@ -1,5 +1,7 @@
 package model
 
+//go:generate go2sql Language Keyword Person Teacher
+
 import (
 	"html/template"
 	"time"
@@ -16,7 +18,7 @@ type MyString string
 type Language struct {
 	ID         uint `go2sql:",id,primary-key"`
 	Name       string
-	WordsCount uint `go2sql:"word_stat" db:"words_count"`
+	WordsCount uint `go2sql:"words_stat" db:"words_count"`
 
 	// WordsCount *uint
 	// HTML template.HTML
@@ -31,25 +33,26 @@ type Language struct {
 	Field6 string
 	Field7 string
 
-	// Info
-	// Origin Origin `go2sql:",inline"`
+	Info
+	Origin Info `go2sql:",prefix:origin_"`
 	// Name Type     `go2sql:"name2"`
 
-	AuthorID uint
+	AuthorID *uint
 	Author   *Person
 
 	Embed struct {
 		Name string
-	}
+	} `go2sql:",json"`
 
 	MyString MyString
+	Aliases  []string
 
 	Rule inflect.Rule
 
 	// TODO: support array
 	Keywords []*Keyword
 
-	HTML template.HTML // TODO: convert it to string(HTML) when scanning
+	HTML template.HTML
 
 	Teachers []*Teacher
 	// LanguagesTeachers []LanguageTeacher
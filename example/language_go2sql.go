@@ -1,83 +1,164 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash ccbfed8f30dd55dbe2cd460f959a0edc12b30795a656857e7019275c5e03f340
+
 package model
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 
 	"github.com/bom-d-van/go2sql/go2sql"
 )
 
 const (
-	// LanguageTableName = "languages"
-
-	LanguageColumnID         = "id"
-	LanguageColumnName       = "name"
-	LanguageColumnWordsCount = "words_stat"
-	LanguageColumnAuthor     = "author"
-	LanguageColumnKeywords   = "keywords"
-	LanguageColumnTeachers   = "teachers"
+	LanguageColumnID                = "id"
+	LanguageColumnName              = "name"
+	LanguageColumnWordsCount        = "words_stat"
+	LanguageColumnField1            = "field1"
+	LanguageColumnField2            = "field2"
+	LanguageColumnField3            = "field3"
+	LanguageColumnField4            = "field4"
+	LanguageColumnField5            = "field5"
+	LanguageColumnField6            = "field6"
+	LanguageColumnField7            = "field7"
+	LanguageColumnCreatedAt         = "created_at"
+	LanguageColumnDescription       = "description"
+	LanguageColumnOriginCreatedAt   = "origin_created_at"
+	LanguageColumnOriginDescription = "origin_description"
+	LanguageColumnAuthorID          = "author_id"
+	LanguageColumnEmbed             = "embed"
+	LanguageColumnMyString          = "my_string"
+	LanguageColumnAliases           = "aliases"
+	LanguageColumnHTML              = "html"
+	LanguageColumnTeacherID         = "teacher_id"
+	LanguageColumnAuthor            = "author"
+	LanguageColumnKeywords          = "keywords"
+	LanguageColumnTeachers          = "teachers"
+)
 
-	// TODO
+var (
+	LanguageID                = go2sql.Column{Table: "languages", Name: LanguageColumnID, GoName: "ID", GoType: "uint", PrimaryKey: true}
+	LanguageName              = go2sql.Column{Table: "languages", Name: LanguageColumnName, GoName: "Name", GoType: "string"}
+	LanguageWordsCount        = go2sql.Column{Table: "languages", Name: LanguageColumnWordsCount, GoName: "WordsCount", GoType: "uint"}
+	LanguageField1            = go2sql.Column{Table: "languages", Name: LanguageColumnField1, GoName: "Field1", GoType: "string"}
+	LanguageField2            = go2sql.Column{Table: "languages", Name: LanguageColumnField2, GoName: "Field2", GoType: "string"}
+	LanguageField3            = go2sql.Column{Table: "languages", Name: LanguageColumnField3, GoName: "Field3", GoType: "string"}
+	LanguageField4            = go2sql.Column{Table: "languages", Name: LanguageColumnField4, GoName: "Field4", GoType: "string"}
+	LanguageField5            = go2sql.Column{Table: "languages", Name: LanguageColumnField5, GoName: "Field5", GoType: "string"}
+	LanguageField6            = go2sql.Column{Table: "languages", Name: LanguageColumnField6, GoName: "Field6", GoType: "string"}
+	LanguageField7            = go2sql.Column{Table: "languages", Name: LanguageColumnField7, GoName: "Field7", GoType: "string"}
+	LanguageCreatedAt         = go2sql.Column{Table: "languages", Name: LanguageColumnCreatedAt, GoName: "Info.CreatedAt", GoType: "time.Time"}
+	LanguageDescription       = go2sql.Column{Table: "languages", Name: LanguageColumnDescription, GoName: "Info.Description", GoType: "string"}
+	LanguageOriginCreatedAt   = go2sql.Column{Table: "languages", Name: LanguageColumnOriginCreatedAt, GoName: "Origin.CreatedAt", GoType: "time.Time"}
+	LanguageOriginDescription = go2sql.Column{Table: "languages", Name: LanguageColumnOriginDescription, GoName: "Origin.Description", GoType: "string"}
+	LanguageAuthorID          = go2sql.Column{Table: "languages", Name: LanguageColumnAuthorID, GoName: "AuthorID", GoType: "*uint", Nullable: true}
+	LanguageEmbed             = go2sql.Column{Table: "languages", Name: LanguageColumnEmbed, GoName: "Embed", GoType: "struct{Name string}"}
+	LanguageMyString          = go2sql.Column{Table: "languages", Name: LanguageColumnMyString, GoName: "MyString", GoType: "MyString"}
+	LanguageAliases           = go2sql.Column{Table: "languages", Name: LanguageColumnAliases, GoName: "Aliases", GoType: "[]string"}
+	LanguageHTML              = go2sql.Column{Table: "languages", Name: LanguageColumnHTML, GoName: "HTML", GoType: "template.HTML"}
+	LanguageTeacherID         = go2sql.Column{Table: "languages", Name: LanguageColumnTeacherID, GoName: "TeacherID", GoType: "uint"}
 )
 
+// LanguageColumns is the registry of the columns of languages, which the
+// selected and updated columns are checked against.
+var LanguageColumns = go2sql.Columns{LanguageID, LanguageName, LanguageWordsCount, LanguageField1, LanguageField2, LanguageField3, LanguageField4, LanguageField5, LanguageField6, LanguageField7, LanguageCreatedAt, LanguageDescription, LanguageOriginCreatedAt, LanguageOriginDescription, LanguageAuthorID, LanguageEmbed, LanguageMyString, LanguageAliases, LanguageHTML, LanguageTeacherID}
+
 var (
+	LanguageAllColumns       = LanguageColumns.Names()
 	LanguageAllRelatedTables = []string{LanguageColumnAuthor, LanguageColumnKeywords, LanguageColumnTeachers}
 )
 
 type Languages []*Language
 
-func FirstLanguage(optsx ...go2sql.QueryOption) (l *Language, err error)  { return }
-func FirstLanguages(optsx ...go2sql.QueryOption) (l Languages, err error) { return }
-func LastLanguage(optsx ...go2sql.QueryOption) (l *Language, err error)   { return }
-func LastLanguages(optsx ...go2sql.QueryOption) (l Languages, err error)  { return }
+// go2sqlFields returns the fields of the columns, which are looked up in
+// LanguageColumns, to scan into or pass as arguments.
+func (l *Language) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&l.ID, &l.Name, &l.WordsCount, &l.Field1, &l.Field2, &l.Field3, &l.Field4, &l.Field5, &l.Field6, &l.Field7, &l.Info.CreatedAt, &l.Info.Description, &l.Origin.CreatedAt, &l.Origin.Description, &l.AuthorID, go2sql.JSON(&l.Embed), (*string)(&l.MyString), go2sql.Array(dialect, &l.Aliases), (*string)(&l.HTML), &l.TeacherID}
+	for _, column := range columns {
+		i, ok := LanguageColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
+	return
+}
+
+func (l *Language) IsEmptyRow() bool {
+	if l == nil {
+		return true
+	}
+
+	return l.ID == 0 &&
+		l.Name == "" &&
+		l.WordsCount == 0 &&
+		l.Field1 == "" &&
+		l.Field2 == "" &&
+		l.Field3 == "" &&
+		l.Field4 == "" &&
+		l.Field5 == "" &&
+		l.Field6 == "" &&
+		l.Field7 == "" &&
+		l.Info.CreatedAt.IsZero() &&
+		l.Info.Description == "" &&
+		l.Origin.CreatedAt.IsZero() &&
+		l.Origin.Description == "" &&
+		l.AuthorID == nil &&
+		l.Author.IsEmptyRow() &&
+		l.MyString == "" &&
+		len(l.Aliases) == 0 &&
+		len(l.Keywords) == 0 &&
+		l.HTML == "" &&
+		len(l.Teachers) == 0 &&
+		l.TeacherID == 0
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (l *Language) IsNewRow() bool {
+	if l == nil {
+		return true
+	}
+
+	return l.ID == 0
+}
 
 func FindLanguage(optsx ...go2sql.QueryOption) (l *Language, err error) {
 	opts := go2sql.QueryOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
-	l = &Language{}
-	var fields []interface{}
-	var columns []string
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := LanguageAllColumns
 	if sel, ok := opts.GetSelect(); ok {
 		columns = []string(sel)
-		for _, c := range columns {
-			switch c {
-			case LanguageColumnID:
-				fields = append(fields, &l.ID)
-			case LanguageColumnName:
-				fields = append(fields, &l.Name)
-			case LanguageColumnWordsCount:
-				fields = append(fields, &l.WordsCount)
-			default:
-				err = fmt.Errorf("go2sql: unknown column %s", c)
-				return
-			}
-		}
-	} else {
-		columns = []string{"id", "name", "words_stat", "field1", "field2", "field3", "field4", "field5", "field6", "field7"}
-		fields = []interface{}{&l.ID, &l.Name, &l.WordsCount, &l.Field1, &l.Field2, &l.Field3, &l.Field4, &l.Field5, &l.Field6, &l.Field7}
+	}
+	l = &Language{}
+	fields, err := l.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
 	}
 
-	var sql go2sql.SQL
+	var query go2sql.SQL
 	if opt, ok := opts.GetSQL(); ok && opt.Full {
-		sql = opt
+		query = opt
 	} else {
-		sql.SQL = fmt.Sprintf("select %s from languages %s", strings.Join(columns, ","), opt.SQL)
-		sql.Args = opt.Args
+		query.SQL = fmt.Sprintf("SELECT `languages`.`%s` FROM `languages` %s", strings.Join(columns, "`, `languages`.`"), opt.SQL)
+		query.Args = opt.Args
 	}
+	query = query.Expand()
 
-	err = db.QueryRow(sql.SQL, sql.Args...).Scan(fields...)
-	if err != nil {
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "languages", "find", query.SQL)
 		return
 	}
 
@@ -85,17 +166,20 @@ func FindLanguage(optsx ...go2sql.QueryOption) (l *Language, err error) {
 		for _, table := range tables {
 			switch table.Name {
 			case LanguageColumnAuthor:
-				err = l.FetchAuthor(go2sql.DB(db), table.Tables)
+				err = l.FetchAuthor(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
 			case LanguageColumnKeywords:
-				err = l.FetchKeywords(go2sql.DB(db), table.Tables)
+				err = l.FetchKeywords(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
 			case LanguageColumnTeachers:
-				err = l.FetchTeachers(go2sql.DB(db), table.Tables)
+				err = l.FetchTeachers(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
 			}
 			if err != nil {
 				return
 			}
 		}
 	}
+
 	return
 }
 
@@ -103,89 +187,72 @@ func FindLanguages(optsx ...go2sql.QueryOption) (ls Languages, err error) {
 	opts := go2sql.QueryOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
-	var columns []string
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := LanguageAllColumns
 	if sel, ok := opts.GetSelect(); ok {
 		columns = []string(sel)
-	} else {
-		columns = []string{"id", "name", "words_stat", "field1", "field2", "field3", "field4", "field5", "field6", "field7"}
+	}
+	if _, err = (&Language{}).go2sqlFields(columns, dialect); err != nil {
+		return
 	}
 
-	var sql go2sql.SQL
+	var query go2sql.SQL
 	if opt, ok := opts.GetSQL(); ok && opt.Full {
-		sql = opt
+		query = opt
 	} else {
-		sql.SQL = fmt.Sprintf("select %s from languages %s", strings.Join(columns, ","), opt.SQL)
-		sql.Args = opt.Args
+		query.SQL = fmt.Sprintf("SELECT `languages`.`%s` FROM `languages` %s", strings.Join(columns, "`, `languages`.`"), opt.SQL)
+		query.Args = opt.Args
 	}
+	query = query.Expand()
 
-	rows, err := db.Query(sql.SQL, sql.Args...)
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
 	if err != nil {
+		err = go2sql.WrapError(err, dialect, "languages", "find", query.SQL)
 		return
 	}
-
 	defer func() {
 		if er := rows.Close(); er != nil {
 			if err != nil {
 				log.Println(er)
 			} else {
-				err = er
+				err = go2sql.WrapError(er, dialect, "languages", "find", query.SQL)
 			}
 		}
 	}()
 
 	for rows.Next() {
 		var l Language
-		var fields []interface{}
-		for _, c := range columns {
-			switch c {
-			case LanguageColumnID:
-				fields = append(fields, &l.ID)
-			case LanguageColumnName:
-				fields = append(fields, &l.Name)
-			case LanguageColumnWordsCount:
-				fields = append(fields, &l.WordsCount)
-			case "field1":
-				fields = append(fields, &l.Field1)
-			case "field2":
-				fields = append(fields, &l.Field2)
-			case "field3":
-				fields = append(fields, &l.Field3)
-			case "field4":
-				fields = append(fields, &l.Field4)
-			case "field5":
-				fields = append(fields, &l.Field5)
-			case "field6":
-				fields = append(fields, &l.Field6)
-			case "field7":
-				fields = append(fields, &l.Field7)
-			default:
-				err = fmt.Errorf("go2sql: unknown column %s", c)
-				return
-			}
-		}
-
-		err = rows.Scan(fields...)
-		if err != nil {
+		fields, _ := l.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "languages", "find", query.SQL)
 			return
 		}
 		ls = append(ls, &l)
 	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "languages", "find", query.SQL)
+		return
+	}
 
 	if tables, ok := opts.GetTables(); ok {
 		for _, table := range tables {
 			switch table.Name {
 			case LanguageColumnAuthor:
-				err = ls.FetchAuthor(go2sql.DB(db), table.Tables)
+				err = ls.FetchAuthor(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
 			case LanguageColumnKeywords:
-				err = ls.FetchKeywords(go2sql.DB(db), table.Tables)
+				err = ls.FetchKeywords(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
 			case LanguageColumnTeachers:
-				err = ls.FetchTeachers(go2sql.DB(db), table.Tables)
+				err = ls.FetchTeachers(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
 			}
 			if err != nil {
 				return
@@ -196,231 +263,207 @@ func FindLanguages(optsx ...go2sql.QueryOption) (ls Languages, err error) {
 	return
 }
 
-func (l *Language) IsEmptyRow() bool {
-	if l == nil {
-		return true
-	}
-
-	return l.ID == 0 &&
-		l.Name == "" &&
-		l.WordsCount == 0 &&
-		l.AuthorID == 0 &&
-		l.Author.IsEmptyRow() &&
-		len(l.Keywords) == 0 &&
-		len(l.Teachers) == 0
-}
-
-// At least one of primary keys is not zero value
-func (l *Language) IsNewRow() bool {
-	if l == nil {
-		return true
-	}
-	return l.ID == 0
-}
-
-// func init() {
-// 	var l Language
-// 	var db *sql.DB
-// 	FindLanguage(go2sql.DB(db), go2sql.NewSQL("limit 1 ordered by id dsc"))
-// }
-
-func (l *Language) Duplicate(optsx ...go2sql.InsertOption) (nl *Language, err error) {
-	return
-}
-
-func (l *Language) ZeroPrimaryKeys() {
-}
-
-func (ls *Languages) Insert(optsx ...go2sql.InsertOption) (err error) {
-	if len(*ls) == 0 {
+func (l *Language) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if !l.IsNewRow() {
 		return
 	}
 
 	opts := go2sql.InsertOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
 	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return l.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
 
 	for _, table := range tables {
 		switch table.Name {
 		case LanguageColumnAuthor:
-			var authors People
-			for _, l := range *ls {
-				if l.Author != nil {
-					authors = append(authors, l.Author)
-				}
+			if l.Author.IsEmptyRow() {
+				continue
 			}
-			if err = authors.Insert(); err != nil {
+			if err = l.Author.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
+			authorIDValue := l.Author.ID
+			l.AuthorID = &authorIDValue
+		case LanguageColumnKeywords, LanguageColumnTeachers:
 		default:
-			err = fmt.Errorf("go2sql: unknown table %s", table)
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
 			return
 		}
 	}
 
-	// TODO: support selects
-	stmt, err := db.Prepare(`INSERT INTO languages (name, words_stat) VALUES(?, ?)`)
+	id, err := go2sql.Insert(ctx, db, dialect, "INSERT INTO `languages` (`name`, `words_stat`, `field1`, `field2`, `field3`, `field4`, `field5`, `field6`, `field7`, `created_at`, `description`, `origin_created_at`, `origin_description`, `author_id`, `embed`, `my_string`, `aliases`, `html`, `teacher_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", "id", l.Name, l.WordsCount, l.Field1, l.Field2, l.Field3, l.Field4, l.Field5, l.Field6, l.Field7, l.Info.CreatedAt, l.Info.Description, l.Origin.CreatedAt, l.Origin.Description, l.AuthorID, go2sql.JSON(&l.Embed), string(l.MyString), go2sql.Array(dialect, &l.Aliases), string(l.HTML), l.TeacherID)
 	if err != nil {
+		err = go2sql.WrapError(err, dialect, "languages", "insert", "INSERT INTO `languages` (`name`, `words_stat`, `field1`, `field2`, `field3`, `field4`, `field5`, `field6`, `field7`, `created_at`, `description`, `origin_created_at`, `origin_description`, `author_id`, `embed`, `my_string`, `aliases`, `html`, `teacher_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 		return
 	}
-	defer func() {
-		er := stmt.Close()
-		if err == nil {
-			err = er
-		} else {
-			log.Println(er)
-		}
-	}()
-	for _, l := range *ls {
-		var r sql.Result
-		r, err = stmt.Exec(l.Name, l.WordsCount)
-		if err != nil {
-			return
-		}
-		var id int64
-		id, err = r.LastInsertId()
-		if err != nil {
-			return
-		}
-		l.ID = uint(id)
-	}
+	l.ID = uint(id)
 
 	for _, table := range tables {
 		switch table.Name {
 		case LanguageColumnKeywords:
 			var keywords Keywords
-			for _, l := range *ls {
-				for _, keyword := range l.Keywords {
-					keyword.LanguageID = l.ID
-					keywords = append(keywords, keyword)
-				}
+			for i := range l.Keywords {
+				keyword := l.Keywords[i]
+				keyword.LanguageID = l.ID
+				keywords = append(keywords, keyword)
 			}
-			if err = keywords.Update(go2sql.DB(db), table.Tables); err != nil {
+			if err = keywords.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
 		case LanguageColumnTeachers:
 			var teachers Teachers
-			for _, l := range *ls {
-				for _, teacher := range l.Teachers {
-					teacher.LanguageID = l.ID
-					teachers = append(teachers, teacher)
-				}
+			for i := range l.Teachers {
+				teacher := l.Teachers[i]
+				teachers = append(teachers, teacher)
 			}
-			if err = teachers.Update(go2sql.DB(db), table.Tables); err != nil {
+			if err = teachers.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+			if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "insert", "DELETE FROM `languages_teachers_xref` WHERE `language_id` = ?", l.ID); err != nil {
 				return
 			}
 			for _, teacher := range teachers {
-				if _, err = db.Exec("INSERT INTO languages_teachers_xref (language_id, teacher_id) VALUES (?, ?)", teacher.LanguageID, teacher.ID); err != nil {
+				if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "insert", "INSERT INTO `languages_teachers_xref` (`language_id`, `teacher_id`) VALUES (?, ?)", l.ID, teacher.ID); err != nil {
 					return
 				}
 			}
-			// teachers := Teachers(l.Teachers)
-			// if err = teachers.Update(go2sql.DB(db), table.Tables); err != nil {
-			// 	return
-			// }
-			// for index := range l.Teachers {
-			// 	if _, err = db.Exec("INSERT INTO languages_teachers_xref (language_id, teacher_id) VALUES (?, ?)", l.ID, l.Teachers[index].ID); err != nil {
-			// 		return
-			// 	}
-			// }
-		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
-			return
 		}
 	}
 
 	return
 }
 
-func (l *Language) Insert(optsx ...go2sql.InsertOption) (err error) {
-	if !l.IsNewRow() {
+func (ls *Languages) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*ls) == 0 {
 		return
 	}
 
 	opts := go2sql.InsertOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
 	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return ls.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
 
 	for _, table := range tables {
 		switch table.Name {
 		case LanguageColumnAuthor:
-			if l.Author.IsEmptyRow() {
-				continue
+			var people People
+			for _, l := range *ls {
+				if l.IsNewRow() && !l.Author.IsEmptyRow() {
+					people = append(people, l.Author)
+				}
 			}
-			if err = l.Author.Insert(go2sql.DB(db), table.Tables); err != nil {
+			if err = people.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
-			l.AuthorID = l.Author.ID
+			for _, l := range *ls {
+				if l.IsNewRow() && !l.Author.IsEmptyRow() {
+					authorIDValue := l.Author.ID
+					l.AuthorID = &authorIDValue
+				}
+			}
+		case LanguageColumnKeywords, LanguageColumnTeachers:
 		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
 			return
 		}
 	}
 
-	// TODO: support selects
-	r, err := db.Exec(`INSERT INTO languages (name, words_stat) VALUES(?, ?)`, l.Name, l.WordsCount)
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `languages` (`name`, `words_stat`, `field1`, `field2`, `field3`, `field4`, `field5`, `field6`, `field7`, `created_at`, `description`, `origin_created_at`, `origin_description`, `author_id`, `embed`, `my_string`, `aliases`, `html`, `teacher_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", "id")
 	if err != nil {
+		err = go2sql.WrapError(err, dialect, "languages", "insert", "INSERT INTO `languages` (`name`, `words_stat`, `field1`, `field2`, `field3`, `field4`, `field5`, `field6`, `field7`, `created_at`, `description`, `origin_created_at`, `origin_description`, `author_id`, `embed`, `my_string`, `aliases`, `html`, `teacher_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 		return
 	}
-	id, err := r.LastInsertId()
-	if err != nil {
-		return
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "languages", "insert", "INSERT INTO `languages` (`name`, `words_stat`, `field1`, `field2`, `field3`, `field4`, `field5`, `field6`, `field7`, `created_at`, `description`, `origin_created_at`, `origin_description`, `author_id`, `embed`, `my_string`, `aliases`, `html`, `teacher_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			}
+		}
+	}()
+	for _, l := range *ls {
+		if !l.IsNewRow() {
+			continue
+		}
+		id, err := stmt.Exec(ctx, l.Name, l.WordsCount, l.Field1, l.Field2, l.Field3, l.Field4, l.Field5, l.Field6, l.Field7, l.Info.CreatedAt, l.Info.Description, l.Origin.CreatedAt, l.Origin.Description, l.AuthorID, go2sql.JSON(&l.Embed), string(l.MyString), go2sql.Array(dialect, &l.Aliases), string(l.HTML), l.TeacherID)
+		if err != nil {
+			return go2sql.WrapError(err, dialect, "languages", "insert", "INSERT INTO `languages` (`name`, `words_stat`, `field1`, `field2`, `field3`, `field4`, `field5`, `field6`, `field7`, `created_at`, `description`, `origin_created_at`, `origin_description`, `author_id`, `embed`, `my_string`, `aliases`, `html`, `teacher_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		}
+		l.ID = uint(id)
 	}
-	l.ID = uint(id)
 
 	for _, table := range tables {
 		switch table.Name {
 		case LanguageColumnKeywords:
-			for index := range l.Keywords {
-				l.Keywords[index].LanguageID = l.ID
-				// if err := l.Keywords[index].Update(db); err != nil {
-				// 	return
-				// }
-			}
-			keywords := Keywords(l.Keywords)
-			if err = keywords.Update(go2sql.DB(db), table.Tables); err != nil {
+			var keywords Keywords
+			for _, l := range *ls {
+				for i := range l.Keywords {
+					keyword := l.Keywords[i]
+					keyword.LanguageID = l.ID
+					keywords = append(keywords, keyword)
+				}
+			}
+			if err = keywords.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
 		case LanguageColumnTeachers:
-			teachers := Teachers(l.Teachers)
-			if err = teachers.Update(go2sql.DB(db), table.Tables); err != nil {
+			var teachers Teachers
+			for _, l := range *ls {
+				for i := range l.Teachers {
+					teacher := l.Teachers[i]
+					teachers = append(teachers, teacher)
+				}
+			}
+			if err = teachers.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
-			for index := range l.Teachers {
-				// if l.Teachers[index].ID <= 0 {
-				// 	if _, err = l.Teachers[index].Insert(db); err != nil {
-				// 		return
-				// 	}
-				// }
-				if _, err = db.Exec("INSERT INTO languages_teachers_xref (language_id, teacher_id) VALUES (?, ?)", l.ID, l.Teachers[index].ID); err != nil {
+			for _, l := range *ls {
+				if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "insert", "DELETE FROM `languages_teachers_xref` WHERE `language_id` = ?", l.ID); err != nil {
 					return
 				}
+				for i := range l.Teachers {
+					teacher := l.Teachers[i]
+					if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "insert", "INSERT INTO `languages_teachers_xref` (`language_id`, `teacher_id`) VALUES (?, ?)", l.ID, teacher.ID); err != nil {
+						return
+					}
+				}
 			}
-		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
-			return
 		}
 	}
 
 	return
 }
 
+// Update inserts the row if it's new, or updates all its columns otherwise.
 func (l *Language) Update(optsx ...go2sql.UpdateOption) (err error) {
 	if l == nil {
 		return
@@ -429,35 +472,44 @@ func (l *Language) Update(optsx ...go2sql.UpdateOption) (err error) {
 	opts := go2sql.UpdateOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
 	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return l.Update(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
 
 	for _, table := range tables {
 		switch table.Name {
 		case LanguageColumnAuthor:
-			if l.Author.IsNewRow() {
+			if l.Author.IsEmptyRow() {
 				continue
 			}
-			if err = l.Author.Update(go2sql.DB(db), table.Tables); err != nil {
+			if err = l.Author.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
-			l.AuthorID = l.Author.ID
+			authorIDValue := l.Author.ID
+			l.AuthorID = &authorIDValue
+		case LanguageColumnKeywords, LanguageColumnTeachers:
 		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
 			return
 		}
 	}
 
 	if l.IsNewRow() {
-		err = l.Insert(go2sql.DB(db))
+		err = l.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
 	} else {
-		_, err = db.Exec(`UPDATE languages SET name = ?, words_stat = ? WHERE id = ?`, l.Name, l.WordsCount, l.ID)
+		_, err = go2sql.Exec(ctx, db, dialect, "languages", "update", "UPDATE `languages` SET `name` = ?, `words_stat` = ?, `field1` = ?, `field2` = ?, `field3` = ?, `field4` = ?, `field5` = ?, `field6` = ?, `field7` = ?, `created_at` = ?, `description` = ?, `origin_created_at` = ?, `origin_description` = ?, `author_id` = ?, `embed` = ?, `my_string` = ?, `aliases` = ?, `html` = ?, `teacher_id` = ? WHERE `id` = ?", l.Name, l.WordsCount, l.Field1, l.Field2, l.Field3, l.Field4, l.Field5, l.Field6, l.Field7, l.Info.CreatedAt, l.Info.Description, l.Origin.CreatedAt, l.Origin.Description, l.AuthorID, go2sql.JSON(&l.Embed), string(l.MyString), go2sql.Array(dialect, &l.Aliases), string(l.HTML), l.TeacherID, l.ID)
 	}
 	if err != nil {
 		return
@@ -466,285 +518,337 @@ func (l *Language) Update(optsx ...go2sql.UpdateOption) (err error) {
 	for _, table := range tables {
 		switch table.Name {
 		case LanguageColumnKeywords:
-			for index := range l.Keywords {
-				l.Keywords[index].LanguageID = l.ID
+			var keywords Keywords
+			for i := range l.Keywords {
+				keyword := l.Keywords[i]
+				keyword.LanguageID = l.ID
+				keywords = append(keywords, keyword)
 			}
-			keywords := Keywords(l.Keywords)
-			if err = keywords.Update(go2sql.DB(db), table.Tables); err != nil {
+			if err = keywords.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
 		case LanguageColumnTeachers:
-			teachers := Teachers(l.Teachers)
-			if err = teachers.Update(go2sql.DB(db), table.Tables); err != nil {
+			var teachers Teachers
+			for i := range l.Teachers {
+				teacher := l.Teachers[i]
+				teachers = append(teachers, teacher)
+			}
+			if err = teachers.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
 				return
 			}
-		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
-			return
+			if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "update", "DELETE FROM `languages_teachers_xref` WHERE `language_id` = ?", l.ID); err != nil {
+				return
+			}
+			for _, teacher := range teachers {
+				if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "update", "INSERT INTO `languages_teachers_xref` (`language_id`, `teacher_id`) VALUES (?, ?)", l.ID, teacher.ID); err != nil {
+					return
+				}
+			}
 		}
 	}
 
 	return
 }
 
-func (ls *Languages) Update(optsx ...go2sql.UpdateOption) (err error) {
-	if l == nil {
-		return
-	}
-
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (l *Language) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
 	opts := go2sql.UpdateOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
-	tables, _ := opts.GetTables()
-
-	for _, table := range tables {
-		switch table.Name {
-		case LanguageColumnAuthor:
-			if l.Author.IsNewRow() {
-				continue
-			}
-			if err = l.Author.Update(go2sql.DB(db), table.Tables); err != nil {
-				return
-			}
-			l.AuthorID = l.Author.ID
-		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
-			return
-		}
+	if l.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
 	}
 
-	if l.IsNewRow() {
-		err = l.Insert(go2sql.DB(db))
-	} else {
-		_, err = db.Exec(`UPDATE languages SET name = ?, words_stat = ? WHERE id = ?`, l.Name, l.WordsCount, l.ID)
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
 	}
+	args, err := l.go2sqlFields(sel, dialect)
 	if err != nil {
 		return
 	}
-
-	for _, table := range tables {
-		switch table.Name {
-		case LanguageColumnKeywords:
-			for index := range l.Keywords {
-				l.Keywords[index].LanguageID = l.ID
-			}
-			keywords := Keywords(l.Keywords)
-			if err = keywords.Update(go2sql.DB(db), table.Tables); err != nil {
-				return
-			}
-		case LanguageColumnTeachers:
-			teachers := Teachers(l.Teachers)
-			if err = teachers.Update(go2sql.DB(db), table.Tables); err != nil {
-				return
-			}
-		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
+	var updates []string
+	for _, column := range sel {
+		if c, _ := LanguageColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
 			return
 		}
+		updates = append(updates, "`"+column+"` = ?")
 	}
 
+	args = append(args, l.ID)
+	_, err = go2sql.Exec(ctx, db, dialect, "languages", "update", fmt.Sprintf("UPDATE `languages` SET %s WHERE `id` = ?", strings.Join(updates, ", ")), args...)
 	return
 }
 
-func (l *Language) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+func (ls *Languages) Update(optsx ...go2sql.UpdateOption) (err error) {
 	opts := go2sql.UpdateOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
-	if l.IsNewRow() {
-		// return l.Insert(db)
-		return errors.New("can't not update a new row")
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return ls.Update(append(optsx, go2sql.Tx(tx))...)
+		})
 	}
 
-	var columns []string
-	if sel, ok := opts.GetSelect(); ok {
-		columns = []string(sel)
-	} else {
-		return errors.New("empty select/columns")
-	}
+	optsx = append(optsx, go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
 
-	var args []interface{}
-	var updates []string
-	for _, c := range columns {
-		updates = append(updates, c+" = ?")
-		switch c {
-		case LanguageColumnID:
-			args = append(args, &l.ID)
-		case LanguageColumnName:
-			args = append(args, &l.Name)
-		case LanguageColumnWordsCount:
-			args = append(args, &l.WordsCount)
-		default:
-			err = fmt.Errorf("go2sql: unknown column %s", c)
+	for _, l := range *ls {
+		if err = l.Update(optsx...); err != nil {
 			return
 		}
 	}
-
-	_, err = db.Exec(fmt.Sprintf(`UPDATE languages SET %s where id = ?`, strings.Join(updates, ","), l.ID), args...)
 	return
 }
 
 func (l *Language) Delete(optsx ...go2sql.DeleteOption) (err error) {
-	if l == nil || l.IsEmptyRow() {
+	if l.IsNewRow() {
 		return
 	}
 
 	opts := go2sql.DeleteOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
-	_, err = db.Exec(`DELETE FROM languages WHERE id = ?`, l.ID)
-	if err != nil {
-		return
-	}
 	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return l.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
 	for _, table := range tables {
 		switch table.Name {
-		case LanguageColumnAuthor:
-			err = l.Author.Delete(go2sql.DB(db), table.Tables)
 		case LanguageColumnKeywords:
-			keywords := Keywords(l.Keywords)
-			err = keywords.Delete(go2sql.DB(db), table.Tables)
+			var keywords Keywords
+			for i := range l.Keywords {
+				keywords = append(keywords, l.Keywords[i])
+			}
+			err = keywords.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
 		case LanguageColumnTeachers:
-			teachers := Teachers(l.Teachers)
-			err = teachers.Delete(go2sql.DB(db), table.Tables)
+			var teachers Teachers
+			for i := range l.Teachers {
+				teachers = append(teachers, l.Teachers[i])
+			}
+			err = teachers.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+		case LanguageColumnAuthor:
 		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
 		}
 		if err != nil {
 			return
 		}
 	}
 
+	if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "delete", "DELETE FROM `languages_teachers_xref` WHERE `language_id` = ?", l.ID); err != nil {
+		return
+	}
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "languages", "delete", "DELETE FROM `languages` WHERE `id` = ?", l.ID); err != nil {
+		return
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case LanguageColumnAuthor:
+			if err = l.Author.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
 	return
 }
 
 func (ls *Languages) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, l := range *ls {
+		if !l.IsNewRow() {
+			keys = append(keys, l.ID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
 	opts := go2sql.DeleteOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
-	var ids []interface{}
-	var idsCons []string
-	for _, l := range *ls {
-		ids = append(ids, l.ID)
-		idsCons = append(idsCons, "id = ?")
-	}
-	_, err = db.Exec(`DELETE FROM languages WHERE `+strings.Join(idsCons, " or "), ids...)
-	if err != nil {
-		return
-	}
 	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return ls.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
 	for _, table := range tables {
 		switch table.Name {
-		case LanguageColumnAuthor:
-			var people People
-			for _, l := range *ls {
-				people = append(people, l.Author)
-			}
-			err = people.Delete(go2sql.DB(db))
 		case LanguageColumnKeywords:
 			var keywords Keywords
 			for _, l := range *ls {
-				keywords = append(keywords, l.Keywords...)
+				for i := range l.Keywords {
+					keywords = append(keywords, l.Keywords[i])
+				}
 			}
-			err = keywords.Delete(go2sql.DB(db))
+			err = keywords.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
 		case LanguageColumnTeachers:
 			var teachers Teachers
 			for _, l := range *ls {
-				teachers = append(teachers, l.Teachers...)
+				for i := range l.Teachers {
+					teachers = append(teachers, l.Teachers[i])
+				}
 			}
-			err = teachers.Delete(go2sql.DB(db))
+			err = teachers.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+		case LanguageColumnAuthor:
 		default:
-			err = fmt.Errorf("go2sql: unknown column %s", table)
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
 		}
 		if err != nil {
 			return
 		}
 	}
 
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `languages_teachers_xref` WHERE `language_id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "languages_teachers_xref", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+		query = go2sql.NewSQL("DELETE FROM `languages` WHERE `id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "languages", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	if table, ok := tables.Get(LanguageColumnAuthor); ok {
+		var people People
+		for _, l := range *ls {
+			if !l.Author.IsEmptyRow() {
+				people = append(people, l.Author)
+			}
+		}
+		if err = people.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+			return
+		}
+	}
+
 	return
 }
 
-func (l *Language) FetchKeywords(opts ...go2sql.QueryOption) (err error) {
-	opts = append(opts, go2sql.NewSQL("where language_id = ?", l.ID))
-	l.Keywords, err = FindKeywords(opts...)
+func (l *Language) FetchAuthor(optsx ...go2sql.QueryOption) error {
+	ls := Languages{l}
+	return ls.FetchAuthor(optsx...)
+}
+
+func (ls *Languages) FetchAuthor(optsx ...go2sql.QueryOption) (err error) {
+	var keys []interface{}
+	for _, l := range *ls {
+		l.Author = nil
+		if l.AuthorID != nil {
+			keys = append(keys, *l.AuthorID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
+	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		people, err := FindPeople(append(guestOpts, go2sql.NewSQL("WHERE `id` IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range *ls {
+			for _, person := range people {
+				if l.AuthorID != nil && *l.AuthorID == person.ID {
+					l.Author = person
+				}
+			}
+		}
+	}
 
 	return
 }
 
+func (l *Language) FetchKeywords(optsx ...go2sql.QueryOption) error {
+	ls := Languages{l}
+	return ls.FetchKeywords(optsx...)
+}
+
 func (ls *Languages) FetchKeywords(optsx ...go2sql.QueryOption) (err error) {
-	opts := go2sql.QueryOptions(optsx)
-	db, ok := opts.GetDB()
-	if !ok {
-		db = go2sql.DefaultDB.DB
+	var keys []interface{}
+	for _, l := range *ls {
+		l.Keywords = nil
+		keys = append(keys, l.ID)
 	}
-	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+	if len(keys) == 0 {
 		return
 	}
 
-	var ids []string
-	for _, l := range *ls {
-		ids = append(ids, strconv.Itoa(int(l.ID)))
-	}
-	// rows, err := db.Query(`SELECT id, name, type, language_id FROM keywords WHERE language_id IN (?)`, strings.Join(ids, ","))
-	// if err != nil {
-	// 	return
-	// }
-
-	// defer func() {
-	// 	if er := rows.Close(); er != nil {
-	// 		if err != nil {
-	// 			log.Println(er)
-	// 		} else {
-	// 			err = er
-	// 		}
-	// 	}
-	// }()
-
-	// var keywords []Keyword
-	// for rows.Next() {
-	// 	var k Keyword
-	// 	if err = rows.Scan(&k.ID, &k.Name, &k.Type, &k.LanguageID); err != nil {
-	// 		return
-	// 	}
-	// 	keywords = append(keywords, k)
-	// }
-	keywords, err := FindKeywords(go2sql.DB(db), go2sql.NewSQL("WHERE language_id IN (?)", strings.Join(ids, ",")))
-	if err != nil {
-		return
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
 	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		keywords, err := FindKeywords(append(guestOpts, go2sql.NewSQL("WHERE `language_id` IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
 
-	for i, l := range *ls {
-		for _, keyword := range keywords {
-			if keyword.LanguageID == l.ID {
-				(*ls)[i].Keywords = append((*ls)[i].Keywords, keyword)
+		for _, l := range *ls {
+			for _, keyword := range keywords {
+				if l.ID == keyword.LanguageID {
+					l.Keywords = append(l.Keywords, keyword)
+				}
 			}
 		}
 	}
@@ -752,75 +856,99 @@ func (ls *Languages) FetchKeywords(optsx ...go2sql.QueryOption) (err error) {
 	return
 }
 
-func (l *Language) FetchAuthor(opts ...go2sql.QueryOption) (err error) {
-	// err = db.QueryRow("select id, name, email from people where id = ?", l.AuthorID).Scan(&l.Author.ID, &l.Author.Name, &l.Author.Email)
-	opts = append(opts, go2sql.NewSQL("where language_id = ?", l.ID))
-	l.Author, err = FindPerson(opts...)
-	return
+func (l *Language) FetchTeachers(optsx ...go2sql.QueryOption) error {
+	ls := Languages{l}
+	return ls.FetchTeachers(optsx...)
 }
 
-// func (ls *Languages) FetchAuthor(opts ...go2sql.QueryOption) (err error) {
-// 	// err = db.QueryRow("select id, name, email from people where id = ?", l.AuthorID).Scan(&l.Author.ID, &l.Author.Name, &l.Author.Email)
-// 	var ids []string
-// 	for _, l := range ls {
-// 		ids = append(ids, strconv.Itoa(l.ID))
-// 	}
-// 	// TODO: composite primary keys support
-// 	opts = append(opts, go2sql.NewSQL("where language_id in (?)", strings.Join(ids, ",")))
-// 	l.Author, err = FindPersons(opts...)
-// 	return
-// }
-
-func (ls *Languages) FetchAuthor(optsx ...go2sql.QueryOption) (err error) {
-	if len(*ls) == 0 {
+func (ls *Languages) FetchTeachers(optsx ...go2sql.QueryOption) (err error) {
+	var keys []interface{}
+	for _, l := range *ls {
+		l.Teachers = nil
+		keys = append(keys, l.ID)
+	}
+	if len(keys) == 0 {
 		return
 	}
+
 	opts := go2sql.QueryOptions(optsx)
 	db, ok := opts.GetDB()
 	if !ok {
-		db = go2sql.DefaultDB.DB
+		db = go2sql.DefaultDB
 	}
 	if db == nil {
-		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
+		err = go2sql.ErrNoDB
 		return
 	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
-	var ids []string
-	for _, l := range *ls {
-		ids = append(ids, strconv.Itoa(int(l.AuthorID)))
-	}
-	// rows, err := db.Query(`SELECT id, name, email FROM people WHERE id IN (?)`, strings.Join(ids, ","))
-	// if err != nil {
-	// 	return
-	// }
-
-	// defer func() {
-	// 	if er := rows.Close(); er != nil {
-	// 		if err != nil {
-	// 			log.Println(er)
-	// 		} else {
-	// 			err = er
-	// 		}
-	// 	}
-	// }()
-
-	// var people []*Person
-	// for rows.Next() {
-	// 	var person Person
-	// 	if err = rows.Scan(&person.ID, &person.Name, &person.Email); err != nil {
-	// 		return
-	// 	}
-	// 	people = append(people, &person)
-	// }
-	people, err := FindPeople(go2sql.DB(db), go2sql.NewSQL("WHERE id IN (?)", strings.Join(ids, ", ")))
-	if err != nil {
+	type xref struct {
+		hostID  uint
+		guestID uint
+	}
+	var xrefs []xref
+	var guestKeys []interface{}
+	scan := func(batch []interface{}) (err error) {
+		query := go2sql.NewSQL("SELECT `language_id`, `teacher_id` FROM `languages_teachers_xref` WHERE `language_id` IN (?)", batch).Expand()
+		defer func() { err = go2sql.WrapError(err, dialect, "languages_teachers_xref", "fetch", query.SQL) }()
+		rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+		if err != nil {
+			return
+		}
+		defer func() {
+			if er := rows.Close(); er != nil {
+				if err != nil {
+					log.Println(er)
+				} else {
+					err = go2sql.WrapError(er, dialect, "languages_teachers_xref", "fetch", query.SQL)
+				}
+			}
+		}()
+
+		for rows.Next() {
+			var x xref
+			if err = rows.Scan(&x.hostID, &x.guestID); err != nil {
+				return
+			}
+			xrefs = append(xrefs, x)
+			guestKeys = append(guestKeys, x.guestID)
+		}
+		return rows.Err()
+	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		if err = scan(batch); err != nil {
+			return
+		}
+	}
+	if len(xrefs) == 0 {
 		return
 	}
 
-	for index, l := range *ls {
-		for _, person := range people {
-			if person.ID == l.AuthorID {
-				(*ls)[index].Author = person
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
+	}
+	guestOpts = append(guestOpts, go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+	for _, batch := range go2sql.Batches(guestKeys, go2sql.BatchSize) {
+		teachers, err := FindTeachers(append(guestOpts, go2sql.NewSQL("WHERE `id` IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range *ls {
+			for _, x := range xrefs {
+				if x.hostID == l.ID {
+					for _, teacher := range teachers {
+						if x.guestID == teacher.ID {
+							l.Teachers = append(l.Teachers, teacher)
+						}
+					}
+				}
 			}
 		}
 	}
@@ -828,124 +956,69 @@ func (ls *Languages) FetchAuthor(optsx ...go2sql.QueryOption) (err error) {
 	return
 }
 
-func (l *Language) FetchTeachers(opts ...go2sql.QueryOption) (err error) {
-	// rows, err := db.Query(`SELECT teachers.id, teachers.name, teachers.age FROM teachers
-	// 	INNER JOIN languages_teachers_xref
-	// 	ON teachers.id = languages_teachers_xref.teacher_id
-	// 	WHERE languages_teachers_xref.language_id = ?`, l.ID)
-	// if err != nil {
-	// 	return
-	// }
-
-	// defer func() {
-	// 	if er := rows.Close(); er != nil {
-	// 		if err != nil {
-	// 			log.Println(er)
-	// 		} else {
-	// 			err = er
-	// 		}
-	// 	}
-	// }()
-
-	// for rows.Next() {
-	// 	var t Teacher
-	// 	if err = rows.Scan(&t.ID, &t.Name, &t.Age); err != nil {
-	// 		return
-	// 	}
-	// 	l.Teachers = append(l.Teachers, t)
-	// }
-
-	opts = append(opts, go2sql.NewSQL("where language_id = ?", l.ID))
-	teachers, err := FindTeachers(opts...)
-	l.Teachers = []*Teacher(teachers)
+// LanguageQueryBuilder composes the queries of languages, see LanguageQuery.
+type LanguageQueryBuilder struct {
+	query go2sql.Query
+}
 
-	return
+// LanguageQuery starts a query of languages, e.g.
+// LanguageQuery().Where(cond).OrderBy(order).Limit(10).All().
+func LanguageQuery() *LanguageQueryBuilder {
+	return &LanguageQueryBuilder{}
 }
 
-func (ls *Languages) FetchTeachers(optsx ...go2sql.QueryOption) (err error) {
-	if len(*ls) == 0 {
-		return
-	}
-	opts := go2sql.QueryOptions(optsx)
-	// db, ok := opts.GetDB()
-	// if !ok {
-	// 	if go2sql.DefaultDB == nil {
-	// 		err = errors.New("should specify *sql.DB by go2sql.DB or init go2sql.DefaultDB")
-	// 		return
-	// 	}
-	// 	db = go2sql.DefaultDB.DB
-	// }
-
-	var ids []string
-	for _, l := range *ls {
-		ids = append(ids, strconv.Itoa(int(l.ID)))
-	}
-	// rows, err := db.Query(`SELECT
-	// 		teachers.id,
-	// 		teachers.name,
-	// 		teachers.age,
-	// 		languages_teachers_xref.language_id
-	// 	FROM teachers
-	// 	INNER JOIN languages_teachers_xref
-	// 	ON teachers.id = languages_teachers_xref.teacher_id
-	// 	WHERE languages_teachers_xref.language_id in (?)`, strings.Join(ids, ","))
-	// if err != nil {
-	// 	return
-	// }
-
-	// defer func() {
-	// 	if er := rows.Close(); er != nil {
-	// 		if err != nil {
-	// 			log.Println(er)
-	// 		} else {
-	// 			err = er
-	// 		}
-	// 	}
-	// }()
-
-	// type teacher struct {
-	// 	Teacher
-	// 	languageID uint
-	// }
-
-	// var ts []teacher
-	// for rows.Next() {
-	// 	var t teacher
-	// 	if err = rows.Scan(&t.ID, &t.Name, &t.Age, &t.languageID); err != nil {
-	// 		return
-	// 	}
-	// 	ts = append(ts, t)
-	// }
-
-	// // TODO: support where override?
-	// optsx = append(optsx, go2sql.NewSQL(`SELECT
-	// 		teachers.id,
-	// 		teachers.name,
-	// 		teachers.age,
-	// 	FROM teachers
-	// 	INNER JOIN languages_teachers_xref
-	// 	ON teachers.id = languages_teachers_xref.teacher_id
-	// 	WHERE languages_teachers_xref.language_id in (?)`, strings.Join(ids, ",")))
-	// teachers, err := FindTeachers(optsx...)
-	// if err != nil {
-	// 	return
-	// }
-
-	opts = append(opts, go2sql.NewSQL(`INNER JOIN languages_teachers_xref
-	ON teachers.id = languages_teachers_xref.teacher_id
-	WHERE languages_teachers_xref.language_id in (?)`, strings.Join(ids, ",")))
-	teachers, err := FindTeachers(opts...)
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *LanguageQueryBuilder) Where(conds ...go2sql.SQL) *LanguageQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *LanguageQueryBuilder) Join(join string, args ...interface{}) *LanguageQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *LanguageQueryBuilder) OrderBy(orders ...go2sql.SQL) *LanguageQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *LanguageQueryBuilder) Limit(limit int) *LanguageQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *LanguageQueryBuilder) Offset(offset int) *LanguageQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *LanguageQueryBuilder) With(opts ...go2sql.QueryOption) *LanguageQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *LanguageQueryBuilder) All() (Languages, error) {
+	opts, err := q.query.QueryOptions()
 	if err != nil {
-		return
+		return nil, err
 	}
+	return FindLanguages(opts...)
+}
 
-	for i, l := range *ls {
-		for _, t := range teachers {
-			if t.LanguageID == l.ID {
-				(*ls)[i].Teachers = append((*ls)[i].Teachers, t)
-			}
-		}
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *LanguageQueryBuilder) One() (*Language, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
 	}
-
-	return
+	return FindLanguage(opts...)
 }
@@ -17,7 +17,7 @@ var db *sql.DB
 
 func init() {
 	var err error
-	db, err = sql.Open("mysql", "root:@/go2sql_example")
+	db, err = sql.Open("mysql", "root:@/go2sql_example?parseTime=true")
 	if err != nil {
 		panic(err)
 	}
@@ -42,6 +42,16 @@ func resetDB() {
 			field5 varchar(255) not null default 'text',
 			field6 varchar(255) not null default 'text',
 			field7 varchar(255) not null default 'text',
+			created_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',
+			description varchar(255) NOT NULL DEFAULT '',
+			origin_created_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00',
+			origin_description varchar(255) NOT NULL DEFAULT '',
+			author_id int DEFAULT NULL,
+			embed JSON,
+			my_string varchar(255) NOT NULL DEFAULT '',
+			aliases TEXT,
+			html varchar(255) NOT NULL DEFAULT '',
+			teacher_id int NOT NULL DEFAULT 0,
 			PRIMARY KEY (id)
 		);
 	`))
@@ -126,7 +136,7 @@ func TestFindLanguages(t *testing.T) {
 		t.Fatal(err)
 	}
 	if got, want := len(ls), 99; got != want {
-		t.Error("len(ls) = %d; want %d", got, want)
+		t.Errorf("len(ls) = %d; want %d", got, want)
 	}
 	if got, want := ls[0].Name, "Mr. Tester"; got != want {
 		t.Errorf("ls[0].Name = %s; want %s", got, want)
@@ -138,7 +148,7 @@ func TestFindLanguages(t *testing.T) {
 		t.Fatal(err)
 	}
 	if got, want := len(ls), 10; got != want {
-		t.Error("len(ls) = %d; want %d", got, want)
+		t.Errorf("len(ls) = %d; want %d", got, want)
 	}
 	if got, want := ls[0].ID, uint(99); got != want {
 		t.Errorf("ls[0].Name = %d; want %d", got, want)
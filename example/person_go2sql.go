@@ -0,0 +1,449 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash dd1a516c2700307cc6b7a23908b79e3c5d43cf140b1a15ac3e1a93d633cb31e7
+
+package model
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bom-d-van/go2sql/go2sql"
+)
+
+const (
+	PersonColumnID    = "id"
+	PersonColumnName  = "name"
+	PersonColumnEmail = "email"
+)
+
+var (
+	PersonID    = go2sql.Column{Table: "people", Name: PersonColumnID, GoName: "ID", GoType: "uint", PrimaryKey: true}
+	PersonName  = go2sql.Column{Table: "people", Name: PersonColumnName, GoName: "Name", GoType: "string"}
+	PersonEmail = go2sql.Column{Table: "people", Name: PersonColumnEmail, GoName: "Email", GoType: "string"}
+)
+
+// PersonColumns is the registry of the columns of people, which the
+// selected and updated columns are checked against.
+var PersonColumns = go2sql.Columns{PersonID, PersonName, PersonEmail}
+
+var (
+	PersonAllColumns       = PersonColumns.Names()
+	PersonAllRelatedTables = []string{}
+)
+
+type People []*Person
+
+// go2sqlFields returns the fields of the columns, which are looked up in
+// PersonColumns, to scan into or pass as arguments.
+func (p *Person) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&p.ID, &p.Name, &p.Email}
+	for _, column := range columns {
+		i, ok := PersonColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
+	return
+}
+
+func (p *Person) IsEmptyRow() bool {
+	if p == nil {
+		return true
+	}
+
+	return p.ID == 0 &&
+		p.Name == "" &&
+		p.Email == ""
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (p *Person) IsNewRow() bool {
+	if p == nil {
+		return true
+	}
+
+	return p.ID == 0
+}
+
+func FindPerson(optsx ...go2sql.QueryOption) (p *Person, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := PersonAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	p = &Person{}
+	fields, err := p.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `people`.`%s` FROM `people` %s", strings.Join(columns, "`, `people`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "people", "find", query.SQL)
+		return
+	}
+
+	return
+}
+
+func FindPeople(optsx ...go2sql.QueryOption) (ps People, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := PersonAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	if _, err = (&Person{}).go2sqlFields(columns, dialect); err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `people`.`%s` FROM `people` %s", strings.Join(columns, "`, `people`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "people", "find", query.SQL)
+		return
+	}
+	defer func() {
+		if er := rows.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "people", "find", query.SQL)
+			}
+		}
+	}()
+
+	for rows.Next() {
+		var p Person
+		fields, _ := p.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "people", "find", query.SQL)
+			return
+		}
+		ps = append(ps, &p)
+	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "people", "find", query.SQL)
+		return
+	}
+
+	return
+}
+
+func (p *Person) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if !p.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	id, err := go2sql.Insert(ctx, db, dialect, "INSERT INTO `people` (`name`, `email`) VALUES (?, ?)", "id", p.Name, p.Email)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "people", "insert", "INSERT INTO `people` (`name`, `email`) VALUES (?, ?)")
+		return
+	}
+	p.ID = uint(id)
+
+	return
+}
+
+func (ps *People) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*ps) == 0 {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `people` (`name`, `email`) VALUES (?, ?)", "id")
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "people", "insert", "INSERT INTO `people` (`name`, `email`) VALUES (?, ?)")
+		return
+	}
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "people", "insert", "INSERT INTO `people` (`name`, `email`) VALUES (?, ?)")
+			}
+		}
+	}()
+	for _, p := range *ps {
+		if !p.IsNewRow() {
+			continue
+		}
+		id, err := stmt.Exec(ctx, p.Name, p.Email)
+		if err != nil {
+			return go2sql.WrapError(err, dialect, "people", "insert", "INSERT INTO `people` (`name`, `email`) VALUES (?, ?)")
+		}
+		p.ID = uint(id)
+	}
+
+	return
+}
+
+// Update inserts the row if it's new, or updates all its columns otherwise.
+func (p *Person) Update(optsx ...go2sql.UpdateOption) (err error) {
+	if p == nil {
+		return
+	}
+
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if p.IsNewRow() {
+		err = p.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+	} else {
+		_, err = go2sql.Exec(ctx, db, dialect, "people", "update", "UPDATE `people` SET `name` = ?, `email` = ? WHERE `id` = ?", p.Name, p.Email, p.ID)
+	}
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (p *Person) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if p.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
+	}
+
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
+	}
+	args, err := p.go2sqlFields(sel, dialect)
+	if err != nil {
+		return
+	}
+	var updates []string
+	for _, column := range sel {
+		if c, _ := PersonColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
+			return
+		}
+		updates = append(updates, "`"+column+"` = ?")
+	}
+
+	args = append(args, p.ID)
+	_, err = go2sql.Exec(ctx, db, dialect, "people", "update", fmt.Sprintf("UPDATE `people` SET %s WHERE `id` = ?", strings.Join(updates, ", ")), args...)
+	return
+}
+
+func (ps *People) Update(optsx ...go2sql.UpdateOption) (err error) {
+
+	for _, p := range *ps {
+		if err = p.Update(optsx...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (p *Person) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	if p.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "people", "delete", "DELETE FROM `people` WHERE `id` = ?", p.ID); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ps *People) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, p := range *ps {
+		if !p.IsNewRow() {
+			keys = append(keys, p.ID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `people` WHERE `id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "people", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// PersonQueryBuilder composes the queries of people, see PersonQuery.
+type PersonQueryBuilder struct {
+	query go2sql.Query
+}
+
+// PersonQuery starts a query of people, e.g.
+// PersonQuery().Where(cond).OrderBy(order).Limit(10).All().
+func PersonQuery() *PersonQueryBuilder {
+	return &PersonQueryBuilder{}
+}
+
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *PersonQueryBuilder) Where(conds ...go2sql.SQL) *PersonQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *PersonQueryBuilder) Join(join string, args ...interface{}) *PersonQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *PersonQueryBuilder) OrderBy(orders ...go2sql.SQL) *PersonQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *PersonQueryBuilder) Limit(limit int) *PersonQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *PersonQueryBuilder) Offset(offset int) *PersonQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *PersonQueryBuilder) With(opts ...go2sql.QueryOption) *PersonQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *PersonQueryBuilder) All() (People, error) {
+	opts, err := q.query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindPeople(opts...)
+}
+
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *PersonQueryBuilder) One() (*Person, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindPerson(opts...)
+}
@@ -0,0 +1,235 @@
+package model
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bom-d-van/go2sql/go2sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteDB opens an in-memory SQLite database with the tables of the example,
+// which the generated code runs on by default until the test ends. Unlike the
+// other tests it needs no MySQL server: go test -run SQLite.
+func sqliteDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection has its own in-memory database
+	db.SetMaxOpenConns(1)
+	for _, s := range []string{
+		`CREATE TABLE languages (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, words_stat INT, field1 TEXT DEFAULT '', field2 TEXT DEFAULT '', field3 TEXT DEFAULT '', field4 TEXT DEFAULT '', field5 TEXT DEFAULT '', field6 TEXT DEFAULT '', field7 TEXT DEFAULT '', created_at DATETIME, description TEXT, origin_created_at DATETIME, origin_description TEXT, author_id INT, embed TEXT, my_string TEXT, aliases TEXT, html TEXT, teacher_id INT)`,
+		`CREATE TABLE people (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT UNIQUE, email TEXT)`,
+		`CREATE TABLE keywords (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, type TEXT, language_id INT)`,
+		`CREATE TABLE teachers (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, age INT, language_id INT)`,
+		`CREATE TABLE languages_teachers_xref (language_id INT, teacher_id INT)`,
+		`CREATE TABLE enrollments (student_id INT, course_id INT, grade TEXT, PRIMARY KEY (student_id, course_id))`,
+		`CREATE TABLE students (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)`,
+		`CREATE TABLE courses (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT)`,
+		`CREATE TABLE attendances (id INTEGER PRIMARY KEY AUTOINCREMENT, day DATETIME, enrollment_student_id INT, enrollment_course_id INT)`,
+	} {
+		if _, err := db.Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defaultDB, defaultDialect := go2sql.DefaultDB, go2sql.DefaultDialect
+	go2sql.SetDefaultDB(db)
+	go2sql.DefaultDialect = go2sql.SQLite
+	t.Cleanup(func() {
+		go2sql.SetDefaultDB(defaultDB)
+		go2sql.DefaultDialect = defaultDialect
+		db.Close()
+	})
+	return db
+}
+
+func TestSQLiteRoundTrip(t *testing.T) {
+	db := sqliteDB(t)
+	tables := go2sql.Tables{{Name: "author"}, {Name: "keywords"}, {Name: "teachers"}}
+
+	golang := &Language{
+		Name:       "Go",
+		WordsCount: 3,
+		HTML:       "<b>go</b>",
+		MyString:   "gopher",
+		Aliases:    []string{"golang", "go lang", `q"uote`},
+		Embed:      struct{ Name string }{"embedded"},
+		Author:     &Person{Name: "Rob"},
+		Info:       Info{Description: "lang"},
+		Origin:     Info{CreatedAt: time.Date(2009, 11, 10, 0, 0, 0, 0, time.UTC), Description: "google"},
+		Keywords:   []*Keyword{{Name: "func"}, {Name: "go"}},
+		Teachers:   []*Teacher{{Name: "T1"}, {Name: "T2"}},
+	}
+	if err := golang.Insert(tables); err != nil {
+		t.Fatal(err)
+	}
+	rust := Languages{{Name: "Rust", Author: &Person{Name: "Graydon"}, Keywords: []*Keyword{{Name: "fn"}}}}
+	if err := rust.Insert(tables); err != nil {
+		t.Fatal(err)
+	}
+
+	ls, err := FindLanguages(go2sql.NewSQL("ORDER BY `id`"), tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != 2 {
+		t.Fatalf("found %d languages; want 2", len(ls))
+	}
+	got := ls[0]
+	if got.Name != "Go" || got.WordsCount != 3 || got.HTML != "<b>go</b>" || got.MyString != "gopher" ||
+		len(got.Aliases) != 3 || got.Aliases[2] != `q"uote` || got.Embed.Name != "embedded" ||
+		got.Description != "lang" || got.Origin.Description != "google" || !got.Origin.CreatedAt.Equal(golang.Origin.CreatedAt) {
+		t.Errorf("found %+v", got)
+	}
+	if got.Author == nil || got.Author.Name != "Rob" || got.AuthorID == nil || *got.AuthorID != got.Author.ID ||
+		len(got.Keywords) != 2 || len(got.Teachers) != 2 {
+		t.Errorf("found the related rows %+v, %+v, %+v", got.Author, got.Keywords, got.Teachers)
+	}
+	if got := ls[1]; got.Aliases != nil || got.Author.Name != "Graydon" || len(got.Keywords) != 1 || len(got.Teachers) != 0 {
+		t.Errorf("found %+v", got)
+	}
+
+	built, err := LanguageQuery().
+		Join("INNER JOIN `keywords` ON `keywords`.`language_id` = `languages`.`id`").
+		Where(KeywordName.In([]string{"func", "fn"}), go2sql.Or(LanguageName.Eq("Go"), LanguageName.Like("R%"))).
+		OrderBy(LanguageID.Desc()).
+		With(go2sql.Tables{{Name: "keywords"}}).
+		All()
+	if err != nil || len(built) != 2 || built[0].Name != "Rust" || len(built[1].Keywords) != 2 {
+		t.Errorf("query = %v, %v", built, err)
+	}
+
+	if none, err := LanguageQuery().Limit(0).All(); err != nil || len(none) != 0 {
+		t.Errorf("Limit(0) = %v, %v; want no rows", none, err)
+	}
+	if _, err := LanguageQuery().With(go2sql.NewSQL("WHERE 1 = 1")).All(); !errors.Is(err, go2sql.ErrQuerySQL) {
+		t.Errorf("With(go2sql.NewSQL) = %v; want %v", err, go2sql.ErrQuerySQL)
+	}
+
+	rs := ls[1]
+	rs.Name = "Rust 2"
+	if err := rs.Update(); err != nil {
+		t.Fatal(err)
+	}
+	rs.WordsCount = 9
+	if err := rs.UpdateColumns(go2sql.Select(LanguageWordsCount)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.UpdateColumns(go2sql.Select(LanguageID)); !errors.Is(err, go2sql.ErrUpdatePrimaryKey) {
+		t.Errorf("UpdateColumns(LanguageID) = %v; want %v", err, go2sql.ErrUpdatePrimaryKey)
+	}
+	if err := rs.UpdateColumns(); !errors.Is(err, go2sql.ErrNoColumns) {
+		t.Errorf("UpdateColumns() = %v; want %v", err, go2sql.ErrNoColumns)
+	}
+	if err := (&Person{Name: "Rob"}).Insert(); !errors.Is(err, go2sql.ErrUniqueViolation) {
+		t.Errorf("duplicate insert = %v; want %v", err, go2sql.ErrUniqueViolation)
+	}
+	found, err := FindLanguage(go2sql.NewSQL("WHERE `id` = ?", rs.ID))
+	if err != nil || found.Name != "Rust 2" || found.WordsCount != 9 {
+		t.Errorf("updated %+v, %v", found, err)
+	}
+
+	if _, err := FindLanguage(go2sql.NewSQL("WHERE `id` = 0")); !errors.Is(err, go2sql.ErrNotFound) || !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("FindLanguage of no row = %v; want %v", err, go2sql.ErrNotFound)
+	}
+
+	if err := ls.Delete(tables); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"languages", "keywords", "teachers", "people", "languages_teachers_xref"} {
+		var n int
+		if err := db.QueryRow("SELECT count(*) FROM " + table).Scan(&n); err != nil || n != 0 {
+			t.Errorf("%s: %d rows left, %v", table, n, err)
+		}
+	}
+}
+
+// TestSQLiteCompositeKeys saves, fetches and deletes Enrollments by their
+// StudentID and CourseID, one key per batch.
+func TestSQLiteCompositeKeys(t *testing.T) {
+	db := sqliteDB(t)
+	batchSize := go2sql.BatchSize
+	go2sql.BatchSize = 1
+	defer func() { go2sql.BatchSize = batchSize }()
+
+	math, art, music := &Course{Title: "math"}, &Course{Title: "art"}, &Course{Title: "music"}
+	if err := (&Courses{math, art, music}).Insert(); err != nil {
+		t.Fatal(err)
+	}
+	ann := &Student{Name: "Ann", Enrollments: []*Enrollment{{CourseID: math.ID, Grade: "A"}, {CourseID: art.ID, Grade: "B"}}}
+	if err := ann.Insert(go2sql.Tables{{Name: "enrollments"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Update upserts the rows of natural keys.
+	e := &Enrollment{StudentID: ann.ID, CourseID: math.ID, Grade: "A+", Attendances: []*Attendance{{Day: time.Now()}, {Day: time.Now()}}}
+	if err := e.Update(go2sql.Tables{{Name: "attendances"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&Enrollment{StudentID: ann.ID, CourseID: music.ID, Grade: "C"}).Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	es, err := FindEnrollments(go2sql.NewSQL("ORDER BY `course_id`"), go2sql.Tables{{Name: "student"}, {Name: "course"}, {Name: "attendances"}})
+	if err != nil || len(es) != 3 {
+		t.Fatalf("found %v, %v; want 3 enrollments", es, err)
+	}
+	for i, want := range []struct {
+		grade, course string
+		attendances   int
+	}{{"A+", "math", 2}, {"B", "art", 0}, {"C", "music", 0}} {
+		if got := es[i]; got.Grade != want.grade || got.Course == nil || got.Course.Title != want.course ||
+			got.Student == nil || got.Student.Name != "Ann" || len(got.Attendances) != want.attendances {
+			t.Errorf("found %+v; want %s of %s with %d attendances", got, want.grade, want.course, want.attendances)
+		}
+	}
+
+	as, err := FindAttendances(go2sql.Tables{{Name: "enrollment"}})
+	if err != nil || len(as) != 2 || as[0].Enrollment == nil || as[0].Enrollment.Grade != "A+" {
+		t.Errorf("found the attendances %v, %v", as, err)
+	}
+	ss, err := FindStudents(go2sql.Tables{{Name: "enrollments"}})
+	if err != nil || len(ss) != 1 || len(ss[0].Enrollments) != 3 {
+		t.Errorf("found the students %v, %v", ss, err)
+	}
+
+	deleted := es[:2]
+	if err := deleted.Delete(go2sql.Tables{{Name: "attendances"}}); err != nil {
+		t.Fatal(err)
+	}
+	left, err := FindEnrollments(go2sql.Tables{{Name: "attendances"}})
+	if err != nil || len(left) != 1 || left[0].CourseID != music.ID {
+		t.Errorf("left %v, %v; want the music enrollment", left, err)
+	}
+	var n int
+	if err := db.QueryRow("SELECT count(*) FROM attendances").Scan(&n); err != nil || n != 0 {
+		t.Errorf("attendances: %d rows left, %v", n, err)
+	}
+}
+
+func TestSQLiteJoinTableError(t *testing.T) {
+	db := sqliteDB(t)
+	tables := go2sql.Tables{{Name: "teachers"}}
+	l := &Language{Name: "Go", Teachers: []*Teacher{{Name: "T1"}}}
+	if err := l.Insert(tables); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("DROP TABLE languages_teachers_xref"); err != nil {
+		t.Fatal(err)
+	}
+
+	for op, err := range map[string]error{
+		"update": l.Update(tables),
+		"fetch":  l.FetchTeachers(),
+		"delete": l.Delete(),
+	} {
+		var e *go2sql.Error
+		if !errors.As(err, &e) || e.Table != "languages_teachers_xref" || e.Op != op {
+			t.Errorf("%s = %v; want a go2sql.Error of %s languages_teachers_xref", op, err, op)
+		}
+	}
+}
@@ -0,0 +1,681 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash f62fd2818fb1b3586eadb8be199a9d0dd05ebf470e5263f6cede8c16ed49c9a7
+
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bom-d-van/go2sql/go2sql"
+)
+
+const (
+	StudentColumnID          = "id"
+	StudentColumnName        = "name"
+	StudentColumnEnrollments = "enrollments"
+)
+
+var (
+	StudentID   = go2sql.Column{Table: "students", Name: StudentColumnID, GoName: "ID", GoType: "uint", PrimaryKey: true}
+	StudentName = go2sql.Column{Table: "students", Name: StudentColumnName, GoName: "Name", GoType: "string"}
+)
+
+// StudentColumns is the registry of the columns of students, which the
+// selected and updated columns are checked against.
+var StudentColumns = go2sql.Columns{StudentID, StudentName}
+
+var (
+	StudentAllColumns       = StudentColumns.Names()
+	StudentAllRelatedTables = []string{StudentColumnEnrollments}
+)
+
+type Students []*Student
+
+// go2sqlFields returns the fields of the columns, which are looked up in
+// StudentColumns, to scan into or pass as arguments.
+func (s *Student) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&s.ID, &s.Name}
+	for _, column := range columns {
+		i, ok := StudentColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
+	return
+}
+
+func (s *Student) IsEmptyRow() bool {
+	if s == nil {
+		return true
+	}
+
+	return s.ID == 0 &&
+		s.Name == "" &&
+		len(s.Enrollments) == 0
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (s *Student) IsNewRow() bool {
+	if s == nil {
+		return true
+	}
+
+	return s.ID == 0
+}
+
+func FindStudent(optsx ...go2sql.QueryOption) (s *Student, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := StudentAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	s = &Student{}
+	fields, err := s.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `students`.`%s` FROM `students` %s", strings.Join(columns, "`, `students`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "students", "find", query.SQL)
+		return
+	}
+
+	if tables, ok := opts.GetTables(); ok {
+		for _, table := range tables {
+			switch table.Name {
+			case StudentColumnEnrollments:
+				err = s.FetchEnrollments(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func FindStudents(optsx ...go2sql.QueryOption) (ss Students, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := StudentAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	if _, err = (&Student{}).go2sqlFields(columns, dialect); err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `students`.`%s` FROM `students` %s", strings.Join(columns, "`, `students`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "students", "find", query.SQL)
+		return
+	}
+	defer func() {
+		if er := rows.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "students", "find", query.SQL)
+			}
+		}
+	}()
+
+	for rows.Next() {
+		var s Student
+		fields, _ := s.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "students", "find", query.SQL)
+			return
+		}
+		ss = append(ss, &s)
+	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "students", "find", query.SQL)
+		return
+	}
+
+	if tables, ok := opts.GetTables(); ok {
+		for _, table := range tables {
+			switch table.Name {
+			case StudentColumnEnrollments:
+				err = ss.FetchEnrollments(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+			default:
+				err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (s *Student) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if !s.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return s.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	id, err := go2sql.Insert(ctx, db, dialect, "INSERT INTO `students` (`name`) VALUES (?)", "id", s.Name)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "students", "insert", "INSERT INTO `students` (`name`) VALUES (?)")
+		return
+	}
+	s.ID = uint(id)
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+			var enrollments Enrollments
+			for i := range s.Enrollments {
+				enrollment := s.Enrollments[i]
+				enrollment.StudentID = s.ID
+				enrollments = append(enrollments, enrollment)
+			}
+			if err = enrollments.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func (ss *Students) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*ss) == 0 {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return ss.Insert(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `students` (`name`) VALUES (?)", "id")
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "students", "insert", "INSERT INTO `students` (`name`) VALUES (?)")
+		return
+	}
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "students", "insert", "INSERT INTO `students` (`name`) VALUES (?)")
+			}
+		}
+	}()
+	for _, s := range *ss {
+		if !s.IsNewRow() {
+			continue
+		}
+		id, err := stmt.Exec(ctx, s.Name)
+		if err != nil {
+			return go2sql.WrapError(err, dialect, "students", "insert", "INSERT INTO `students` (`name`) VALUES (?)")
+		}
+		s.ID = uint(id)
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+			var enrollments Enrollments
+			for _, s := range *ss {
+				for i := range s.Enrollments {
+					enrollment := s.Enrollments[i]
+					enrollment.StudentID = s.ID
+					enrollments = append(enrollments, enrollment)
+				}
+			}
+			if err = enrollments.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// Update inserts the row if it's new, or updates all its columns otherwise.
+func (s *Student) Update(optsx ...go2sql.UpdateOption) (err error) {
+	if s == nil {
+		return
+	}
+
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return s.Update(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+			return
+		}
+	}
+
+	if s.IsNewRow() {
+		err = s.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+	} else {
+		_, err = go2sql.Exec(ctx, db, dialect, "students", "update", "UPDATE `students` SET `name` = ? WHERE `id` = ?", s.Name, s.ID)
+	}
+	if err != nil {
+		return
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+			var enrollments Enrollments
+			for i := range s.Enrollments {
+				enrollment := s.Enrollments[i]
+				enrollment.StudentID = s.ID
+				enrollments = append(enrollments, enrollment)
+			}
+			if err = enrollments.Update(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (s *Student) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if s.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
+	}
+
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
+	}
+	args, err := s.go2sqlFields(sel, dialect)
+	if err != nil {
+		return
+	}
+	var updates []string
+	for _, column := range sel {
+		if c, _ := StudentColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
+			return
+		}
+		updates = append(updates, "`"+column+"` = ?")
+	}
+
+	args = append(args, s.ID)
+	_, err = go2sql.Exec(ctx, db, dialect, "students", "update", fmt.Sprintf("UPDATE `students` SET %s WHERE `id` = ?", strings.Join(updates, ", ")), args...)
+	return
+}
+
+func (ss *Students) Update(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return ss.Update(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	optsx = append(optsx, go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+
+	for _, s := range *ss {
+		if err = s.Update(optsx...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (s *Student) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	if s.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return s.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+			var enrollments Enrollments
+			for i := range s.Enrollments {
+				enrollments = append(enrollments, s.Enrollments[i])
+			}
+			err = enrollments.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "students", "delete", "DELETE FROM `students` WHERE `id` = ?", s.ID); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ss *Students) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, s := range *ss {
+		if !s.IsNewRow() {
+			keys = append(keys, s.ID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	tables, _ := opts.GetTables()
+	if b, ok := go2sql.TxBeginnerOf(db); ok && len(tables) > 0 {
+		return go2sql.WithTxContext(ctx, b, func(tx *sql.Tx) error {
+			return ss.Delete(append(optsx, go2sql.Tx(tx))...)
+		})
+	}
+
+	for _, table := range tables {
+		switch table.Name {
+		case StudentColumnEnrollments:
+			var enrollments Enrollments
+			for _, s := range *ss {
+				for i := range s.Enrollments {
+					enrollments = append(enrollments, s.Enrollments[i])
+				}
+			}
+			err = enrollments.Delete(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx), table.Tables)
+		default:
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownTable, table.Name)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `students` WHERE `id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "students", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (s *Student) FetchEnrollments(optsx ...go2sql.QueryOption) error {
+	ss := Students{s}
+	return ss.FetchEnrollments(optsx...)
+}
+
+func (ss *Students) FetchEnrollments(optsx ...go2sql.QueryOption) (err error) {
+	var keys []interface{}
+	for _, s := range *ss {
+		s.Enrollments = nil
+		keys = append(keys, s.ID)
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// related rows are selected by the relationship, so sql options are not
+	// passed on.
+	var guestOpts go2sql.QueryOptions
+	for _, opt := range optsx {
+		if _, ok := opt.(go2sql.SQL); !ok {
+			guestOpts = append(guestOpts, opt)
+		}
+	}
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		enrollments, err := FindEnrollments(append(guestOpts, go2sql.NewSQL("WHERE `student_id` IN (?)", batch))...)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range *ss {
+			for _, enrollment := range enrollments {
+				if s.ID == enrollment.StudentID {
+					s.Enrollments = append(s.Enrollments, enrollment)
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// StudentQueryBuilder composes the queries of students, see StudentQuery.
+type StudentQueryBuilder struct {
+	query go2sql.Query
+}
+
+// StudentQuery starts a query of students, e.g.
+// StudentQuery().Where(cond).OrderBy(order).Limit(10).All().
+func StudentQuery() *StudentQueryBuilder {
+	return &StudentQueryBuilder{}
+}
+
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *StudentQueryBuilder) Where(conds ...go2sql.SQL) *StudentQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *StudentQueryBuilder) Join(join string, args ...interface{}) *StudentQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *StudentQueryBuilder) OrderBy(orders ...go2sql.SQL) *StudentQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *StudentQueryBuilder) Limit(limit int) *StudentQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *StudentQueryBuilder) Offset(offset int) *StudentQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *StudentQueryBuilder) With(opts ...go2sql.QueryOption) *StudentQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *StudentQueryBuilder) All() (Students, error) {
+	opts, err := q.query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindStudents(opts...)
+}
+
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *StudentQueryBuilder) One() (*Student, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindStudent(opts...)
+}
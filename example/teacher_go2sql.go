@@ -1,37 +1,452 @@
+// Code generated by go2sql 0.2.0. DO NOT EDIT.
+// go2sql hash f5ad1e3b1c86c0ee3b1fabae3b8b72e1eb893ed10405f493bb6e56fd13be0f72
+
 package model
 
-import "github.com/bom-d-van/go2sql/go2sql"
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bom-d-van/go2sql/go2sql"
+)
+
+const (
+	TeacherColumnID         = "id"
+	TeacherColumnName       = "name"
+	TeacherColumnAge        = "age"
+	TeacherColumnLanguageID = "language_id"
+)
+
+var (
+	TeacherID         = go2sql.Column{Table: "teachers", Name: TeacherColumnID, GoName: "ID", GoType: "uint", PrimaryKey: true}
+	TeacherName       = go2sql.Column{Table: "teachers", Name: TeacherColumnName, GoName: "Name", GoType: "string"}
+	TeacherAge        = go2sql.Column{Table: "teachers", Name: TeacherColumnAge, GoName: "Age", GoType: "uint"}
+	TeacherLanguageID = go2sql.Column{Table: "teachers", Name: TeacherColumnLanguageID, GoName: "LanguageID", GoType: "uint"}
+)
+
+// TeacherColumns is the registry of the columns of teachers, which the
+// selected and updated columns are checked against.
+var TeacherColumns = go2sql.Columns{TeacherID, TeacherName, TeacherAge, TeacherLanguageID}
+
+var (
+	TeacherAllColumns       = TeacherColumns.Names()
+	TeacherAllRelatedTables = []string{}
+)
 
 type Teachers []*Teacher
 
-func FindTeachers(opts ...go2sql.QueryOption) (ts Teachers, err error) {
+// go2sqlFields returns the fields of the columns, which are looked up in
+// TeacherColumns, to scan into or pass as arguments.
+func (t *Teacher) go2sqlFields(columns []string, dialect go2sql.Dialect) (fields []interface{}, err error) {
+	all := []interface{}{&t.ID, &t.Name, &t.Age, &t.LanguageID}
+	for _, column := range columns {
+		i, ok := TeacherColumns.Index(column)
+		if !ok {
+			err = fmt.Errorf("%w %s", go2sql.ErrUnknownColumn, column)
+			return
+		}
+		fields = append(fields, all[i])
+	}
+	return
+}
+
+func (t *Teacher) IsEmptyRow() bool {
+	if t == nil {
+		return true
+	}
+
+	return t.ID == 0 &&
+		t.Name == "" &&
+		t.Age == 0 &&
+		t.LanguageID == 0
+}
+
+// IsNewRow reports whether all the primary keys are zero values.
+func (t *Teacher) IsNewRow() bool {
+	if t == nil {
+		return true
+	}
+
+	return t.ID == 0
+}
+
+func FindTeacher(optsx ...go2sql.QueryOption) (t *Teacher, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := TeacherAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	t = &Teacher{}
+	fields, err := t.go2sqlFields(columns, dialect)
+	if err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `teachers`.`%s` FROM `teachers` %s", strings.Join(columns, "`, `teachers`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	if err = db.QueryRowContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...).Scan(fields...); err != nil {
+		err = go2sql.WrapError(err, dialect, "teachers", "find", query.SQL)
+		return
+	}
+
 	return
 }
 
-func (t *Teacher) IsEmptyRow() (is bool)                            { return }
-func (t *Teacher) IsNewRow() (is bool)                              { return }
-func (t *Teachers) Insert(optsx ...go2sql.InsertOption) (err error) { return }
-func (t *Teachers) Update(optsx ...go2sql.UpdateOption) (err error) { return }
-func (t *Teachers) Delete(optsx ...go2sql.DeleteOption) (err error) { return }
+func FindTeachers(optsx ...go2sql.QueryOption) (ts Teachers, err error) {
+	opts := go2sql.QueryOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	columns := TeacherAllColumns
+	if sel, ok := opts.GetSelect(); ok {
+		columns = []string(sel)
+	}
+	if _, err = (&Teacher{}).go2sqlFields(columns, dialect); err != nil {
+		return
+	}
+
+	var query go2sql.SQL
+	if opt, ok := opts.GetSQL(); ok && opt.Full {
+		query = opt
+	} else {
+		query.SQL = fmt.Sprintf("SELECT `teachers`.`%s` FROM `teachers` %s", strings.Join(columns, "`, `teachers`.`"), opt.SQL)
+		query.Args = opt.Args
+	}
+	query = query.Expand()
+
+	rows, err := db.QueryContext(ctx, go2sql.Rebind(dialect, query.SQL), query.Args...)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "teachers", "find", query.SQL)
+		return
+	}
+	defer func() {
+		if er := rows.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "teachers", "find", query.SQL)
+			}
+		}
+	}()
+
+	for rows.Next() {
+		var t Teacher
+		fields, _ := t.go2sqlFields(columns, dialect)
+		if err = rows.Scan(fields...); err != nil {
+			err = go2sql.WrapError(err, dialect, "teachers", "find", query.SQL)
+			return
+		}
+		ts = append(ts, &t)
+	}
+	if err = rows.Err(); err != nil {
+		err = go2sql.WrapError(err, dialect, "teachers", "find", query.SQL)
+		return
+	}
+
+	return
+}
 
 func (t *Teacher) Insert(optsx ...go2sql.InsertOption) (err error) {
-	// re, err := db.Exec(`INSERT INTO teachers (name, age) VALUES(?, ?)`, t.Name, t.Age)
-	// if err != nil {
-	// 	return r, err
-	// }
+	if !t.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	id, err := go2sql.Insert(ctx, db, dialect, "INSERT INTO `teachers` (`name`, `age`, `language_id`) VALUES (?, ?, ?)", "id", t.Name, t.Age, t.LanguageID)
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "teachers", "insert", "INSERT INTO `teachers` (`name`, `age`, `language_id`) VALUES (?, ?, ?)")
+		return
+	}
+	t.ID = uint(id)
+
+	return
+}
+
+func (ts *Teachers) Insert(optsx ...go2sql.InsertOption) (err error) {
+	if len(*ts) == 0 {
+		return
+	}
+
+	opts := go2sql.InsertOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	stmt, err := go2sql.PrepareInsert(ctx, db, dialect, "INSERT INTO `teachers` (`name`, `age`, `language_id`) VALUES (?, ?, ?)", "id")
+	if err != nil {
+		err = go2sql.WrapError(err, dialect, "teachers", "insert", "INSERT INTO `teachers` (`name`, `age`, `language_id`) VALUES (?, ?, ?)")
+		return
+	}
+	defer func() {
+		if er := stmt.Close(); er != nil {
+			if err != nil {
+				log.Println(er)
+			} else {
+				err = go2sql.WrapError(er, dialect, "teachers", "insert", "INSERT INTO `teachers` (`name`, `age`, `language_id`) VALUES (?, ?, ?)")
+			}
+		}
+	}()
+	for _, t := range *ts {
+		if !t.IsNewRow() {
+			continue
+		}
+		id, err := stmt.Exec(ctx, t.Name, t.Age, t.LanguageID)
+		if err != nil {
+			return go2sql.WrapError(err, dialect, "teachers", "insert", "INSERT INTO `teachers` (`name`, `age`, `language_id`) VALUES (?, ?, ?)")
+		}
+		t.ID = uint(id)
+	}
+
+	return
+}
+
+// Update inserts the row if it's new, or updates all its columns otherwise.
+func (t *Teacher) Update(optsx ...go2sql.UpdateOption) (err error) {
+	if t == nil {
+		return
+	}
+
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if t.IsNewRow() {
+		err = t.Insert(go2sql.DB(db), go2sql.WithDialect(dialect), go2sql.WithContext(ctx))
+	} else {
+		_, err = go2sql.Exec(ctx, db, dialect, "teachers", "update", "UPDATE `teachers` SET `name` = ?, `age` = ?, `language_id` = ? WHERE `id` = ?", t.Name, t.Age, t.LanguageID, t.ID)
+	}
+	if err != nil {
+		return
+	}
 
-	// var id int64
-	// if id, err = re.LastInsertId(); err != nil {
-	// 	return r, err
-	// }
-	// l.Teachers[i].ID = uint(id)
 	return
 }
 
-func (t *Teacher) Update(optsx ...go2sql.UpdateOption) (err error) { return }
-func (t *Teacher) Delete(optsx ...go2sql.DeleteOption) (err error) { return }
+// UpdateColumns updates the columns specified by go2sql.Selects.
+func (t *Teacher) UpdateColumns(optsx ...go2sql.UpdateOption) (err error) {
+	opts := go2sql.UpdateOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
 
-// func (t *Teacher) IsEmptyRow() bool {
-// 	empty := Teacher{}
-// 	return *t == empty
-// }
+	if t.IsNewRow() {
+		err = go2sql.ErrNewRow
+		return
+	}
+
+	sel, ok := opts.GetSelect()
+	if !ok || len(sel) == 0 {
+		err = go2sql.ErrNoColumns
+		return
+	}
+	args, err := t.go2sqlFields(sel, dialect)
+	if err != nil {
+		return
+	}
+	var updates []string
+	for _, column := range sel {
+		if c, _ := TeacherColumns.Get(column); c.PrimaryKey {
+			err = fmt.Errorf("%w %s", go2sql.ErrUpdatePrimaryKey, column)
+			return
+		}
+		updates = append(updates, "`"+column+"` = ?")
+	}
+
+	args = append(args, t.ID)
+	_, err = go2sql.Exec(ctx, db, dialect, "teachers", "update", fmt.Sprintf("UPDATE `teachers` SET %s WHERE `id` = ?", strings.Join(updates, ", ")), args...)
+	return
+}
+
+func (ts *Teachers) Update(optsx ...go2sql.UpdateOption) (err error) {
+
+	for _, t := range *ts {
+		if err = t.Update(optsx...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (t *Teacher) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	if t.IsNewRow() {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	if _, err = go2sql.Exec(ctx, db, dialect, "teachers", "delete", "DELETE FROM `teachers` WHERE `id` = ?", t.ID); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ts *Teachers) Delete(optsx ...go2sql.DeleteOption) (err error) {
+	var keys []interface{}
+	for _, t := range *ts {
+		if !t.IsNewRow() {
+			keys = append(keys, t.ID)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	opts := go2sql.DeleteOptions(optsx)
+	db, ok := opts.GetDB()
+	if !ok {
+		db = go2sql.DefaultDB
+	}
+	if db == nil {
+		err = go2sql.ErrNoDB
+		return
+	}
+	dialect := opts.GetDialect()
+	ctx := opts.GetContext()
+
+	for _, batch := range go2sql.Batches(keys, go2sql.BatchSize) {
+		query := go2sql.NewSQL("DELETE FROM `teachers` WHERE `id` IN (?)", batch).Expand()
+		if _, err = go2sql.Exec(ctx, db, dialect, "teachers", "delete", query.SQL, query.Args...); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// TeacherQueryBuilder composes the queries of teachers, see TeacherQuery.
+type TeacherQueryBuilder struct {
+	query go2sql.Query
+}
+
+// TeacherQuery starts a query of teachers, e.g.
+// TeacherQuery().Where(cond).OrderBy(order).Limit(10).All().
+func TeacherQuery() *TeacherQueryBuilder {
+	return &TeacherQueryBuilder{}
+}
+
+// Where adds conditions, which are joined by AND, e.g. made by the columns or
+// go2sql.NewSQL.
+func (q *TeacherQueryBuilder) Where(conds ...go2sql.SQL) *TeacherQueryBuilder {
+	q.query.Wheres = append(q.query.Wheres, conds...)
+	return q
+}
+
+// Join adds a join clause, e.g. "INNER JOIN `b` ON `b`.`a_id` = `a`.`id`".
+func (q *TeacherQueryBuilder) Join(join string, args ...interface{}) *TeacherQueryBuilder {
+	q.query.Joins = append(q.query.Joins, go2sql.NewSQL(join, args...))
+	return q
+}
+
+func (q *TeacherQueryBuilder) OrderBy(orders ...go2sql.SQL) *TeacherQueryBuilder {
+	q.query.Orders = append(q.query.Orders, orders...)
+	return q
+}
+
+func (q *TeacherQueryBuilder) Limit(limit int) *TeacherQueryBuilder {
+	q.query.Limit = &limit
+	return q
+}
+
+func (q *TeacherQueryBuilder) Offset(offset int) *TeacherQueryBuilder {
+	q.query.Offset = offset
+	return q
+}
+
+// With adds the other options of the query, e.g. go2sql.Selects or
+// go2sql.Tables. A go2sql.SQL fails the query with go2sql.ErrQuerySQL.
+func (q *TeacherQueryBuilder) With(opts ...go2sql.QueryOption) *TeacherQueryBuilder {
+	q.query.Options = append(q.query.Options, opts...)
+	return q
+}
+
+// All returns the rows matching the query.
+func (q *TeacherQueryBuilder) All() (Teachers, error) {
+	opts, err := q.query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindTeachers(opts...)
+}
+
+// One returns the first row matching the query, or go2sql.ErrNotFound.
+func (q *TeacherQueryBuilder) One() (*Teacher, error) {
+	query := q.query
+	one := 1
+	query.Limit = &one
+	opts, err := query.QueryOptions()
+	if err != nil {
+		return nil, err
+	}
+	return FindTeacher(opts...)
+}
@@ -0,0 +1,201 @@
+package go2sql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ArrayFormat encodes the elements of the array columns as text.
+type ArrayFormat interface {
+	FormatArray(elems []string) string
+	ParseArray(text string) ([]string, error)
+}
+
+var (
+	// PostgresArray is the text format of Postgres arrays, e.g. {1,2,"a b"}.
+	PostgresArray ArrayFormat = postgresArray{}
+
+	// DefaultArrayFormat encodes the array columns as text in the databases
+	// without arrays, i.e. MySQL and SQLite.
+	DefaultArrayFormat = PostgresArray
+)
+
+// DelimitedArray joins the elements by sep, which they must not contain,
+// e.g. DelimitedArray(",") for a,b,c.
+func DelimitedArray(sep string) ArrayFormat { return delimitedArray(sep) }
+
+type delimitedArray string
+
+func (d delimitedArray) FormatArray(elems []string) string {
+	return strings.Join(elems, string(d))
+}
+
+func (d delimitedArray) ParseArray(text string) ([]string, error) {
+	if text == "" {
+		return []string{}, nil
+	}
+	return strings.Split(text, string(d)), nil
+}
+
+type postgresArray struct{}
+
+func (postgresArray) FormatArray(elems []string) string {
+	quoted := make([]string, len(elems))
+	for i, e := range elems {
+		if e != "" && !strings.EqualFold(e, "NULL") && !strings.ContainsAny(e, `{},"\ `+"\t\n\r") {
+			quoted[i] = e
+			continue
+		}
+		quoted[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(e) + `"`
+	}
+	return "{" + strings.Join(quoted, ",") + "}"
+}
+
+func (postgresArray) ParseArray(text string) ([]string, error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("go2sql: invalid array %q", text)
+	}
+	text = text[1 : len(text)-1]
+	elems := []string{}
+	for len(text) > 0 {
+		var elem strings.Builder
+		if text[0] == '"' {
+			i := 1
+			for ; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+				if i < len(text) {
+					elem.WriteByte(text[i])
+				}
+			}
+			if i >= len(text) {
+				return nil, errors.New("go2sql: unterminated quote in array")
+			}
+			text = text[i+1:]
+		} else {
+			i := strings.IndexByte(text, ',')
+			if i < 0 {
+				i = len(text)
+			}
+			if strings.EqualFold(text[:i], "NULL") {
+				return nil, errors.New("go2sql: NULL elements in arrays are not supported")
+			}
+			elem.WriteString(strings.TrimSpace(text[:i]))
+			text = text[i:]
+		}
+		elems = append(elems, elem.String())
+
+		if len(text) > 0 {
+			if text[0] != ',' {
+				return nil, fmt.Errorf("go2sql: unexpected %q in array", text[0])
+			}
+			text = text[1:]
+		}
+	}
+	return elems, nil
+}
+
+// ArrayValue stores the slice V points to in an array column, encoded by
+// Format. It's used by the generated code for the slices of basic types.
+type ArrayValue struct {
+	V      interface{}
+	Format ArrayFormat
+}
+
+// Array wraps v, a pointer to a slice field, to scan and write it in the
+// array format of the dialect.
+func Array(d Dialect, v interface{}) ArrayValue {
+	return ArrayValue{V: v, Format: d.ArrayFormat()}
+}
+
+// Scan decodes src into the slice, setting it to nil on NULL.
+func (a ArrayValue) Scan(src interface{}) error {
+	slice := reflect.ValueOf(a.V).Elem()
+	var text string
+	switch src := src.(type) {
+	case nil:
+		slice.Set(reflect.Zero(slice.Type()))
+		return nil
+	case []byte:
+		text = string(src)
+	case string:
+		text = src
+	default:
+		return fmt.Errorf("go2sql: can't scan %T as array", src)
+	}
+
+	elems, err := a.Format.ParseArray(text)
+	if err != nil {
+		return err
+	}
+	values := reflect.MakeSlice(slice.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		if err := parseElem(values.Index(i), elem); err != nil {
+			return err
+		}
+	}
+	slice.Set(values)
+	return nil
+}
+
+// Value encodes the slice, or NULL if it's nil.
+func (a ArrayValue) Value() (driver.Value, error) {
+	slice := reflect.ValueOf(a.V).Elem()
+	if slice.IsNil() {
+		return nil, nil
+	}
+	elems := make([]string, slice.Len())
+	for i := range elems {
+		elems[i] = formatElem(slice.Index(i))
+	}
+	return a.Format.FormatArray(elems), nil
+}
+
+func parseElem(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if s == "t" || s == "f" {
+			b, err = s == "t", nil
+		}
+		v.SetBool(b)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		v.SetInt(i)
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		v.SetUint(u)
+		return err
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		v.SetFloat(f)
+		return err
+	}
+	return fmt.Errorf("go2sql: unsupported array element %s", v.Type())
+}
+
+func formatElem(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,100 @@
+package go2sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPostgresArray(t *testing.T) {
+	cases := []struct {
+		elems []string
+		text  string
+	}{
+		{[]string{}, `{}`},
+		{[]string{"a", "b"}, `{a,b}`},
+		{[]string{""}, `{""}`},
+		{[]string{"a b", "c,d", `{}`}, `{"a b","c,d","{}"}`},
+		{[]string{`q"uote`, `back\slash`}, `{"q\"uote","back\\slash"}`},
+		{[]string{"NULL", "null"}, `{"NULL","null"}`},
+		{[]string{"tab\tnew\nline"}, "{\"tab\tnew\nline\"}"},
+	}
+	for _, c := range cases {
+		if got := PostgresArray.FormatArray(c.elems); got != c.text {
+			t.Errorf("FormatArray(%q) = %s; want %s", c.elems, got, c.text)
+		}
+		if got, err := PostgresArray.ParseArray(c.text); err != nil || !reflect.DeepEqual(got, c.elems) {
+			t.Errorf("ParseArray(%s) = %q, %v; want %q", c.text, got, err, c.elems)
+		}
+	}
+
+	// the spaces around unquoted elements are insignificant
+	if got, err := PostgresArray.ParseArray(`{ a , b}`); err != nil || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("ParseArray with spaces = %q, %v", got, err)
+	}
+	for _, text := range []string{``, `a,b`, `{"a}`, `{"a"b}`, `{a,NULL}`, `{NULL}`} {
+		if got, err := PostgresArray.ParseArray(text); err == nil {
+			t.Errorf("ParseArray(%s) = %q; want an error", text, got)
+		}
+	}
+}
+
+func TestDelimitedArray(t *testing.T) {
+	format := DelimitedArray("|")
+	for _, elems := range [][]string{{}, {"a"}, {"a", "b c", ""}} {
+		got, err := format.ParseArray(format.FormatArray(elems))
+		if err != nil || !reflect.DeepEqual(got, elems) {
+			t.Errorf("round trip of %q = %q, %v", elems, got, err)
+		}
+	}
+}
+
+func TestArrayValue(t *testing.T) {
+	cases := []struct {
+		v    interface{} // pointer to the slice
+		want interface{}
+	}{
+		{&[]string{"a", `b"c`, `d\e`, "NULL"}, `{a,"b\"c","d\\e","NULL"}`},
+		{&[]int64{-1, 0, 2}, `{-1,0,2}`},
+		{&[]uint{1, 2}, `{1,2}`},
+		{&[]bool{true, false}, `{true,false}`},
+		{&[]float64{1.5, -2}, `{1.5,-2}`},
+		{&[]string{}, `{}`},
+		{new([]string), nil},
+	}
+	for _, c := range cases {
+		got, err := Array(PostgreSQL, c.v).Value()
+		if err != nil || got != c.want {
+			t.Errorf("Value(%v) = %#v, %v; want %#v", c.v, got, err, c.want)
+			continue
+		}
+
+		scanned := reflect.New(reflect.TypeOf(c.v).Elem())
+		if err := Array(PostgreSQL, scanned.Interface()).Scan(got); err != nil {
+			t.Errorf("Scan(%#v) = %v", got, err)
+			continue
+		}
+		if want := reflect.ValueOf(c.v).Elem().Interface(); !reflect.DeepEqual(scanned.Elem().Interface(), want) {
+			t.Errorf("round trip of %#v = %#v", want, scanned.Elem().Interface())
+		}
+	}
+
+	ints := []int{1}
+	if err := Array(SQLite, &ints).Scan([]byte(`{2,3}`)); err != nil || !reflect.DeepEqual(ints, []int{2, 3}) {
+		t.Errorf("Scan([]byte) = %v, %v", ints, err)
+	}
+	if err := Array(SQLite, &ints).Scan(nil); err != nil || ints != nil {
+		t.Errorf("Scan(nil) = %v, %v; want nil", ints, err)
+	}
+	for _, src := range []interface{}{1, `{a}`, `{1,x}`, `{256}`} {
+		small := []int8{}
+		if err := Array(SQLite, &small).Scan(src); err == nil {
+			t.Errorf("Scan(%v) = %v; want an error", src, small)
+		}
+	}
+
+	// bools are t and f in the text of Postgres arrays
+	var bools []bool
+	if err := Array(PostgreSQL, &bools).Scan(`{t,f}`); err != nil || !reflect.DeepEqual(bools, []bool{true, false}) {
+		t.Errorf("Scan({t,f}) = %v, %v", bools, err)
+	}
+}
@@ -0,0 +1,51 @@
+package go2sql
+
+// Column describes a column of a table, e.g. LanguageName of the generated
+// code. It makes the conditions and orders of the query builders, e.g.
+// LanguageName.Eq("Go").
+type Column struct {
+	Table      string // sql name of the table
+	Name       string // sql name of the column
+	GoName     string // field, e.g. Origin.CreatedAt
+	GoType     string
+	Nullable   bool
+	PrimaryKey bool
+}
+
+// String returns the column qualified by the table, e.g. `languages`.`name`.
+func (c Column) String() string { return "`" + c.Table + "`.`" + c.Name + "`" }
+
+// Columns is the registry of the columns of a table, e.g. LanguageColumns.
+type Columns []Column
+
+// Names returns the sql names of the columns.
+func (cs Columns) Names() (names []string) {
+	for _, c := range cs {
+		names = append(names, c.Name)
+	}
+	return
+}
+
+// Get returns the column of the sql name.
+func (cs Columns) Get(name string) (Column, bool) {
+	if i, ok := cs.Index(name); ok {
+		return cs[i], true
+	}
+	return Column{}, false
+}
+
+// Index returns the index of the column of the sql name.
+func (cs Columns) Index(name string) (int, bool) {
+	for i, c := range cs {
+		if c.Name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// Select selects or updates the columns, e.g.
+// FindLanguages(go2sql.Select(LanguageID, LanguageName)).
+func Select(cs ...Column) Selects {
+	return Selects(Columns(cs).Names())
+}
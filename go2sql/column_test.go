@@ -0,0 +1,28 @@
+package go2sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	id := Column{Table: "languages", Name: "id", PrimaryKey: true}
+	name := Column{Table: "languages", Name: "name"}
+	cs := Columns{id, name}
+
+	if got, want := cs.Names(), []string{"id", "name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v; want %v", got, want)
+	}
+	if i, ok := cs.Index("name"); !ok || i != 1 {
+		t.Errorf("Index(name) = %d, %t; want 1, true", i, ok)
+	}
+	if c, ok := cs.Get("id"); !ok || c != id {
+		t.Errorf("Get(id) = %v, %t; want %v, true", c, ok, id)
+	}
+	if _, ok := cs.Index("missing"); ok {
+		t.Error("Index(missing) is found")
+	}
+	if _, ok := cs.Get("missing"); ok {
+		t.Error("Get(missing) is found")
+	}
+}
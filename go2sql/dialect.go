@@ -0,0 +1,277 @@
+package go2sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Dialect renders the sql of a database. The generated code writes its sql
+// with ? placeholders and `quoted` identifiers, which Rebind translates by
+// the dialect.
+type Dialect interface {
+	// Placeholder returns the placeholder of the nth argument, counting from 1.
+	Placeholder(n int) string
+	// Quote quotes an identifier, which might be qualified, e.g. db.languages.
+	Quote(identifier string) string
+	// Returning returns the clause ending an INSERT statement to return the
+	// generated column, or an empty string if sql.Result.LastInsertId works.
+	Returning(column string) string
+	// Limit returns the clause limiting the rows, skipping offset of them
+	// first. A negative limit means no limit.
+	Limit(limit, offset int) string
+	// Upsert returns the clause ending an INSERT statement to update the
+	// columns of the row which conflicts on the keys.
+	Upsert(keys, columns []string) string
+	// ArrayFormat returns the format of the array columns.
+	ArrayFormat() ArrayFormat
+	// TranslateError translates the driver errors of the violated unique
+	// and foreign key constraints to ones matching ErrUniqueViolation and
+	// ErrForeignKeyViolation, returning the other errors as they are.
+	TranslateError(err error) error
+}
+
+var (
+	MySQL      Dialect = mysql{}
+	PostgreSQL Dialect = postgresql{}
+	SQLite     Dialect = sqlite{}
+
+	// DefaultDialect is used by the generated code unless another one is set
+	// by WithDialect.
+	DefaultDialect = MySQL
+)
+
+type dialectOption struct{ Dialect }
+
+// WithDialect sets the dialect of the sql.
+func WithDialect(d Dialect) dialectOption { return dialectOption{d} }
+func (dialectOption) InsertOption()       {}
+func (dialectOption) DeleteOption()       {}
+func (dialectOption) UpdateOption()       {}
+func (dialectOption) QueryOption()        {}
+
+type mysql struct{}
+
+func (mysql) Placeholder(int) string         { return "?" }
+func (mysql) Quote(identifier string) string { return quote(identifier, "`") }
+func (mysql) Returning(string) string        { return "" }
+func (mysql) Limit(limit, offset int) string {
+	return limitOffset(limit, offset, "18446744073709551615")
+}
+func (mysql) ArrayFormat() ArrayFormat { return DefaultArrayFormat }
+func (d mysql) Upsert(keys, columns []string) string {
+	var sets []string
+	for _, c := range columns {
+		sets = append(sets, fmt.Sprintf("%s = VALUES(%s)", d.Quote(c), d.Quote(c)))
+	}
+	if len(sets) == 0 {
+		// keeps the row as it is
+		sets = append(sets, fmt.Sprintf("%s = %s", d.Quote(keys[0]), d.Quote(keys[0])))
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+func (mysql) TranslateError(err error) error {
+	return translateError(err, []string{"Error 1062"}, []string{"Error 1451", "Error 1452"})
+}
+
+type postgresql struct{}
+
+func (postgresql) Placeholder(n int) string         { return fmt.Sprintf("$%d", n) }
+func (postgresql) Quote(identifier string) string   { return quote(identifier, `"`) }
+func (d postgresql) Returning(column string) string { return "RETURNING " + d.Quote(column) }
+func (postgresql) Limit(limit, offset int) string   { return limitOffset(limit, offset, "ALL") }
+func (postgresql) ArrayFormat() ArrayFormat         { return PostgresArray }
+func (d postgresql) Upsert(keys, columns []string) string {
+	return onConflict(d, keys, columns)
+}
+func (postgresql) TranslateError(err error) error {
+	// lib/pq and pgx report the SQLSTATE of the errors.
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		switch state.SQLState() {
+		case "23505":
+			return constraintError{ErrUniqueViolation, err}
+		case "23503":
+			return constraintError{ErrForeignKeyViolation, err}
+		}
+		return err
+	}
+	return translateError(err, []string{"violates unique constraint"}, []string{"violates foreign key constraint"})
+}
+
+type sqlite struct{}
+
+func (sqlite) Placeholder(int) string         { return "?" }
+func (sqlite) Quote(identifier string) string { return quote(identifier, `"`) }
+func (sqlite) Returning(string) string        { return "" }
+func (sqlite) Limit(limit, offset int) string { return limitOffset(limit, offset, "-1") }
+func (sqlite) ArrayFormat() ArrayFormat       { return DefaultArrayFormat }
+func (d sqlite) Upsert(keys, columns []string) string {
+	return onConflict(d, keys, columns)
+}
+func (sqlite) TranslateError(err error) error {
+	return translateError(err, []string{"UNIQUE constraint failed"}, []string{"FOREIGN KEY constraint failed"})
+}
+
+func quote(identifier, q string) string {
+	parts := strings.Split(identifier, ".")
+	for i, p := range parts {
+		parts[i] = q + strings.Replace(p, q, q+q, -1) + q
+	}
+	return strings.Join(parts, ".")
+}
+
+func limitOffset(limit, offset int, all string) string {
+	if offset <= 0 {
+		if limit < 0 {
+			return ""
+		}
+		return fmt.Sprintf("LIMIT %d", limit)
+	}
+	if limit < 0 {
+		return fmt.Sprintf("LIMIT %s OFFSET %d", all, offset)
+	}
+	return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+}
+
+func onConflict(d Dialect, keys, columns []string) string {
+	var quoted, sets []string
+	for _, k := range keys {
+		quoted = append(quoted, d.Quote(k))
+	}
+	for _, c := range columns {
+		sets = append(sets, fmt.Sprintf("%s = excluded.%s", d.Quote(c), d.Quote(c)))
+	}
+	if len(sets) == 0 {
+		return fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", strings.Join(quoted, ", "))
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(quoted, ", "), strings.Join(sets, ", "))
+}
+
+// Rebind translates the ? placeholders and `quoted` identifiers of query to
+// the dialect. String literals and identifiers quoted by double quotes are
+// left as they are.
+func Rebind(d Dialect, query string) string {
+	var buf strings.Builder
+	n := 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; c {
+		case '?':
+			n++
+			buf.WriteString(d.Placeholder(n))
+		case '`':
+			end := strings.IndexByte(query[i+1:], '`')
+			if end < 0 {
+				buf.WriteString(query[i:])
+				return buf.String()
+			}
+			buf.WriteString(d.Quote(query[i+1 : i+1+end]))
+			i += end + 1
+		case '\'', '"':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				buf.WriteString(query[i:])
+				return buf.String()
+			}
+			buf.WriteString(query[i : i+end+2])
+			i += end + 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+// InsertStmt is a prepared INSERT statement returning the generated id the
+// way the dialect supports.
+type InsertStmt struct {
+	stmt      *sql.Stmt
+	id        bool
+	returning bool
+
+	// db and query run the statement when db can't prepare it.
+	db    Executor
+	query string
+}
+
+// PrepareInsert prepares the INSERT statement query for the dialect. The
+// generated value of idColumn is returned by Exec, unless it's empty.
+func PrepareInsert(ctx context.Context, db Executor, d Dialect, query, idColumn string) (*InsertStmt, error) {
+	query, returning := insertSQL(d, query, idColumn)
+	s := &InsertStmt{id: idColumn != "", returning: returning}
+	p, ok := db.(interface {
+		PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	})
+	if !ok {
+		s.db, s.query = db, query
+		return s, nil
+	}
+	stmt, err := p.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s.stmt = stmt
+	return s, nil
+}
+
+// Exec inserts a row, returning its generated id.
+func (s *InsertStmt) Exec(ctx context.Context, args ...interface{}) (id int64, err error) {
+	if s.stmt == nil {
+		return insert(ctx, s.db, s.query, s.id, s.returning, args)
+	}
+	if s.returning {
+		err = s.stmt.QueryRowContext(ctx, args...).Scan(&id)
+		return
+	}
+	result, err := s.stmt.ExecContext(ctx, args...)
+	if err != nil || !s.id {
+		return
+	}
+	return result.LastInsertId()
+}
+
+func (s *InsertStmt) Close() error {
+	if s.stmt == nil {
+		return nil
+	}
+	return s.stmt.Close()
+}
+
+// Insert runs the INSERT statement query like PrepareInsert and Exec do.
+func Insert(ctx context.Context, db Executor, d Dialect, query, idColumn string, args ...interface{}) (id int64, err error) {
+	query, returning := insertSQL(d, query, idColumn)
+	return insert(ctx, db, query, idColumn != "", returning, args)
+}
+
+// Exec runs the statement query of the table for the dialect, wrapping its
+// error in an *Error of the operation op.
+func Exec(ctx context.Context, db Executor, d Dialect, table, op, query string, args ...interface{}) (sql.Result, error) {
+	result, err := db.ExecContext(ctx, Rebind(d, query), args...)
+	return result, WrapError(err, d, table, op, query)
+}
+
+func insert(ctx context.Context, db Executor, query string, id, returning bool, args []interface{}) (int64, error) {
+	if returning {
+		var id int64
+		err := db.QueryRowContext(ctx, query, args...).Scan(&id)
+		return id, err
+	}
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil || !id {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func insertSQL(d Dialect, query, idColumn string) (string, bool) {
+	query = Rebind(d, query)
+	if idColumn == "" {
+		return query, false
+	}
+	if returning := d.Returning(idColumn); returning != "" {
+		return query + " " + returning, true
+	}
+	return query, false
+}
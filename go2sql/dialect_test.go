@@ -0,0 +1,129 @@
+package go2sql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	cases := []struct {
+		d     Dialect
+		query string
+		want  string
+	}{
+		{MySQL, "SELECT `id` FROM `db.languages` WHERE `name` = ? AND id > ?", "SELECT `id` FROM `db`.`languages` WHERE `name` = ? AND id > ?"},
+		{PostgreSQL, "SELECT `id` FROM `db.languages` WHERE `name` = ? AND id > ?", `SELECT "id" FROM "db"."languages" WHERE "name" = $1 AND id > $2`},
+		{SQLite, "SELECT `id` FROM `db.languages` WHERE `name` = ? AND id > ?", `SELECT "id" FROM "db"."languages" WHERE "name" = ? AND id > ?`},
+
+		// string literals and double quoted identifiers are left as they are
+		{PostgreSQL, "SELECT `a` FROM t WHERE b = '?`c`' AND \"d?\" = ?", `SELECT "a" FROM t WHERE b = '?` + "`c`" + `' AND "d?" = $1`},
+		{SQLite, "WHERE `a` = 'it''s ?' AND b = ?", `WHERE "a" = 'it''s ?' AND b = ?`},
+		{MySQL, "WHERE `a` = '?' AND b = ?", "WHERE `a` = '?' AND b = ?"},
+
+		// identifiers holding quotes are escaped
+		{MySQL, "`a``", "`a``"},
+		{PostgreSQL, "`a\"b`", `"a""b"`},
+
+		// unterminated quotes are copied
+		{PostgreSQL, "WHERE a = ? AND `b", "WHERE a = $1 AND `b"},
+		{PostgreSQL, "WHERE a = 'b ?", "WHERE a = 'b ?"},
+	}
+	for _, c := range cases {
+		if got := Rebind(c.d, c.query); got != c.want {
+			t.Errorf("Rebind(%T, %s) = %s; want %s", c.d, c.query, got, c.want)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	cases := []struct {
+		d             Dialect
+		limit, offset int
+		want          string
+	}{
+		{MySQL, -1, 0, ""},
+		{MySQL, 0, 0, "LIMIT 0"},
+		{MySQL, 10, 0, "LIMIT 10"},
+		{MySQL, 10, 5, "LIMIT 10 OFFSET 5"},
+		{MySQL, -1, 5, "LIMIT 18446744073709551615 OFFSET 5"},
+		{PostgreSQL, -1, 0, ""},
+		{PostgreSQL, 10, 5, "LIMIT 10 OFFSET 5"},
+		{PostgreSQL, -1, 5, "LIMIT ALL OFFSET 5"},
+		{SQLite, -1, 0, ""},
+		{SQLite, 10, 5, "LIMIT 10 OFFSET 5"},
+		{SQLite, -1, 5, "LIMIT -1 OFFSET 5"},
+	}
+	for _, c := range cases {
+		if got := c.d.Limit(c.limit, c.offset); got != c.want {
+			t.Errorf("%T.Limit(%d, %d) = %q; want %q", c.d, c.limit, c.offset, got, c.want)
+		}
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	cases := []struct {
+		d             Dialect
+		keys, columns []string
+		want          string
+	}{
+		{MySQL, []string{"a", "b"}, []string{"c", "d"}, "ON DUPLICATE KEY UPDATE `c` = VALUES(`c`), `d` = VALUES(`d`)"},
+		{MySQL, []string{"a", "b"}, nil, "ON DUPLICATE KEY UPDATE `a` = `a`"},
+		{PostgreSQL, []string{"a", "b"}, []string{"c"}, `ON CONFLICT ("a", "b") DO UPDATE SET "c" = excluded."c"`},
+		{PostgreSQL, []string{"a"}, nil, `ON CONFLICT ("a") DO NOTHING`},
+		{SQLite, []string{"a", "b"}, []string{"c", "d"}, `ON CONFLICT ("a", "b") DO UPDATE SET "c" = excluded."c", "d" = excluded."d"`},
+		{SQLite, []string{"a"}, nil, `ON CONFLICT ("a") DO NOTHING`},
+	}
+	for _, c := range cases {
+		if got := c.d.Upsert(c.keys, c.columns); got != c.want {
+			t.Errorf("%T.Upsert(%q, %q) = %s; want %s", c.d, c.keys, c.columns, got, c.want)
+		}
+	}
+}
+
+// pqError reports its SQLSTATE like the errors of lib/pq and pgx.
+type pqError struct{ code, msg string }
+
+func (e *pqError) Error() string    { return e.msg }
+func (e *pqError) SQLState() string { return e.code }
+
+func TestTranslateError(t *testing.T) {
+	cases := []struct {
+		d    Dialect
+		err  error
+		want error // nil if err is returned as it is
+	}{
+		{MySQL, errors.New("Error 1062 (23000): Duplicate entry 'go' for key 'name'"), ErrUniqueViolation},
+		{MySQL, errors.New("Error 1062: Duplicate entry 'go' for key 'name'"), ErrUniqueViolation},
+		{MySQL, errors.New("Error 1452 (23000): Cannot add or update a child row: a foreign key constraint fails"), ErrForeignKeyViolation},
+		{MySQL, errors.New("Error 1451 (23000): Cannot delete or update a parent row: a foreign key constraint fails"), ErrForeignKeyViolation},
+		{MySQL, errors.New("Error 1146 (42S02): Table 'go2sql.nope' doesn't exist"), nil},
+		{PostgreSQL, &pqError{"23505", `pq: duplicate key value violates unique constraint "name"`}, ErrUniqueViolation},
+		{PostgreSQL, &pqError{"23503", `pq: insert or update on table "keywords" violates foreign key constraint "fk"`}, ErrForeignKeyViolation},
+		{PostgreSQL, &pqError{"42P01", `pq: relation "nope" does not exist`}, nil},
+		{PostgreSQL, errors.New(`ERROR: duplicate key value violates unique constraint "name" (SQLSTATE 23505)`), ErrUniqueViolation},
+		{PostgreSQL, errors.New(`ERROR: insert or update on table "keywords" violates foreign key constraint "fk"`), ErrForeignKeyViolation},
+		{SQLite, errors.New("UNIQUE constraint failed: people.name"), ErrUniqueViolation},
+		{SQLite, errors.New("FOREIGN KEY constraint failed"), ErrForeignKeyViolation},
+		{SQLite, errors.New("no such table: nope"), nil},
+	}
+	for _, c := range cases {
+		got := c.d.TranslateError(c.err)
+		if c.want == nil {
+			if got != c.err {
+				t.Errorf("%T.TranslateError(%v) = %v; want it as it is", c.d, c.err, got)
+			}
+			continue
+		}
+		if !errors.Is(got, c.want) {
+			t.Errorf("%T.TranslateError(%v) = %v; want %v", c.d, c.err, got, c.want)
+		}
+		if got.Error() != c.err.Error() || !errors.Is(got, c.err) {
+			t.Errorf("%T.TranslateError(%v) = %v; want it to wrap the driver error", c.d, c.err, got)
+		}
+	}
+
+	var pe *pqError
+	if err := PostgreSQL.TranslateError(&pqError{"23505", "pq: unique"}); !errors.As(err, &pe) || pe.code != "23505" {
+		t.Errorf("the driver error of %v isn't reachable by errors.As", err)
+	}
+}
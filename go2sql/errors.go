@@ -0,0 +1,101 @@
+package go2sql
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned by the generated Find functions of one row,
+	// e.g. FindLanguage, if no row matches. It matches sql.ErrNoRows too.
+	ErrNotFound error = notFoundError{}
+
+	ErrUnknownColumn = errors.New("go2sql: unknown column")
+	ErrUnknownTable  = errors.New("go2sql: unknown table")
+	ErrNoDB          = errors.New("go2sql: should specify the db by go2sql.DB or go2sql.Tx, or init go2sql.DefaultDB")
+	ErrNewRow        = errors.New("go2sql: can't update columns of a new row")
+
+	// ErrNoColumns and ErrUpdatePrimaryKey are returned by UpdateColumns
+	// given no columns, or a primary key column.
+	ErrNoColumns        = errors.New("go2sql: no columns to update")
+	ErrUpdatePrimaryKey = errors.New("go2sql: can't update primary key")
+
+	// ErrQuerySQL is returned by the query builders given an SQL by With,
+	// which is added by Where, Join and OrderBy instead.
+	ErrQuerySQL = errors.New("go2sql: the sql of a query builder is added by Where, Join and OrderBy, not With")
+
+	// ErrUniqueViolation and ErrForeignKeyViolation match the driver errors
+	// of the violated constraints, translated by Dialect.TranslateError.
+	ErrUniqueViolation     = errors.New("go2sql: unique violation")
+	ErrForeignKeyViolation = errors.New("go2sql: foreign key violation")
+)
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string        { return "go2sql: not found" }
+func (notFoundError) Is(target error) bool { return target == sql.ErrNoRows }
+
+// Error is an error of the sql of a generated function, e.g. the INSERT of
+// Language.Insert.
+type Error struct {
+	Table string // e.g. languages
+	Op    string // find, fetch, insert, update or delete
+	SQL   string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return "go2sql: " + e.Op + " " + e.Table + ": " + strings.TrimPrefix(e.Err.Error(), "go2sql: ")
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// WrapError wraps err of the sql query in an *Error, translating
+// sql.ErrNoRows to ErrNotFound and the driver errors by the dialect. Errors
+// which are already an *Error, e.g. the ones of the related rows, are
+// returned as they are.
+func WrapError(err error, d Dialect, table, op, query string) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	if err == sql.ErrNoRows {
+		err = ErrNotFound
+	} else {
+		err = d.TranslateError(err)
+	}
+	return &Error{Table: table, Op: op, SQL: query, Err: err}
+}
+
+// constraintError is a driver error matching the sentinel error of the
+// violated constraint, while it's still reachable by errors.As.
+type constraintError struct {
+	sentinel error
+	err      error
+}
+
+func (e constraintError) Error() string        { return e.err.Error() }
+func (e constraintError) Unwrap() error        { return e.err }
+func (e constraintError) Is(target error) bool { return target == e.sentinel }
+
+// translateError translates err to ErrUniqueViolation or
+// ErrForeignKeyViolation by the messages of the drivers, which aren't
+// imported by go2sql.
+func translateError(err error, unique, foreignKey []string) error {
+	msg := err.Error()
+	for _, s := range unique {
+		if strings.Contains(msg, s) {
+			return constraintError{ErrUniqueViolation, err}
+		}
+	}
+	for _, s := range foreignKey {
+		if strings.Contains(msg, s) {
+			return constraintError{ErrForeignKeyViolation, err}
+		}
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package go2sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWrapError(t *testing.T) {
+	if err := WrapError(nil, MySQL, "languages", "find", "SELECT 1"); err != nil {
+		t.Errorf("WrapError(nil) = %v; want nil", err)
+	}
+
+	driverErr := errors.New("driver: bad connection")
+	err := WrapError(driverErr, MySQL, "languages", "insert", "INSERT INTO `languages` (`name`) VALUES (?)")
+	var e *Error
+	if !errors.As(err, &e) || e.Table != "languages" || e.Op != "insert" || e.SQL != "INSERT INTO `languages` (`name`) VALUES (?)" {
+		t.Fatalf("WrapError = %#v; want an *Error", err)
+	}
+	if !errors.Is(err, driverErr) || errors.Unwrap(err) != driverErr {
+		t.Errorf("%v doesn't unwrap to the driver error", err)
+	}
+	if want := "go2sql: insert languages: driver: bad connection"; err.Error() != want {
+		t.Errorf("Error() = %q; want %q", err.Error(), want)
+	}
+
+	// the errors of the related rows keep their table
+	wrapped := fmt.Errorf("saving: %w", err)
+	if again := WrapError(wrapped, MySQL, "keywords", "insert", "INSERT INTO `keywords`"); again != wrapped {
+		t.Errorf("WrapError(%v) = %v; want it as it is", wrapped, again)
+	}
+
+	if err := WrapError(context.Canceled, MySQL, "languages", "find", "SELECT 1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("WrapError(context.Canceled) = %v", err)
+	}
+
+	// the sentinels wrapped with a detail keep their prefix once
+	err = WrapError(fmt.Errorf("%w %s", ErrUnknownColumn, "nope"), MySQL, "languages", "find", "")
+	if !errors.Is(err, ErrUnknownColumn) || err.Error() != "go2sql: find languages: unknown column nope" {
+		t.Errorf("WrapError(ErrUnknownColumn) = %v", err)
+	}
+}
+
+func TestErrNotFound(t *testing.T) {
+	err := WrapError(sql.ErrNoRows, SQLite, "languages", "find", "SELECT 1")
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("WrapError(sql.ErrNoRows) = %v; want it to match ErrNotFound and sql.ErrNoRows", err)
+	}
+	if errors.Is(sql.ErrNoRows, ErrNotFound) {
+		t.Error("sql.ErrNoRows matches ErrNotFound")
+	}
+	if errors.Is(ErrNotFound, ErrUnknownColumn) {
+		t.Error("ErrNotFound matches ErrUnknownColumn")
+	}
+
+	db := openSQLite(t)
+	var name string
+	err = db.QueryRow("SELECT name FROM rows").Scan(&name)
+	if err = WrapError(err, SQLite, "rows", "find", "SELECT name FROM rows"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("no rows = %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestWrapErrorUniqueViolation(t *testing.T) {
+	db := openSQLite(t)
+	query := "INSERT INTO rows (name) VALUES (?)"
+	if _, err := Exec(context.Background(), db, SQLite, "rows", "insert", query, "a"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Exec(context.Background(), db, SQLite, "rows", "insert", query, "a")
+	var e *Error
+	if !errors.Is(err, ErrUniqueViolation) || errors.Is(err, ErrForeignKeyViolation) || !errors.As(err, &e) || e.SQL != query {
+		t.Fatalf("duplicate insert = %#v; want a unique violation", err)
+	}
+	if want := "go2sql: insert rows: UNIQUE constraint failed: rows.name"; err.Error() != want {
+		t.Errorf("Error() = %q; want %q", err.Error(), want)
+	}
+
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("CREATE TABLE children (name TEXT REFERENCES rows (name))"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Exec(context.Background(), db, SQLite, "children", "insert", "INSERT INTO children (name) VALUES (?)", "b"); !errors.Is(err, ErrForeignKeyViolation) {
+		t.Errorf("orphan insert = %v; want a foreign key violation", err)
+	}
+}
@@ -0,0 +1,213 @@
+package go2sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+)
+
+// BatchSize is the most parameters the batch loaders, e.g.
+// Languages.FetchKeywords, bind in one IN condition, which keeps them under
+// the parameter limits of the drivers, e.g. 999 of older SQLite versions. A
+// composite key counts as many parameters as it has columns.
+var BatchSize = 500
+
+// Expand expands the slice arguments of s into as many placeholders as they
+// have elements, e.g. "id IN (?)" with []int{1, 2} into "id IN (?, ?)" with 1
+// and 2. Elements that are slices expand into row values, e.g. "(a, b) IN (?)"
+// with [][]interface{}{{1, 2}, {3, 4}} into "(a, b) IN ((?, ?), (?, ?))".
+// []byte and driver.Valuer arguments are passed as they are.
+//
+// An empty slice expands into NULL. As "IN (NULL)" is NULL rather than false,
+// which NOT would keep NULL, an empty "IN (?)" is expanded into
+// "IN (NULL) IS TRUE", which is false, and "NOT IN (?)" into
+// "NOT IN (NULL) IS NOT TRUE", which is true. Row values are compared with a
+// row of NULLs, e.g. "(a, b) IN ((NULL, NULL)) IS TRUE".
+//
+// The generated code expands the sql options before Rebind translates the
+// placeholders to the dialect.
+func (s SQL) Expand() SQL {
+	var buf strings.Builder
+	var args []interface{}
+	n := 0
+	for i := 0; i < len(s.SQL); i++ {
+		switch c := s.SQL[i]; c {
+		case '?':
+			if n < len(s.Args) {
+				exp, xargs := expandArg(s.Args[n])
+				if exp == "NULL" && isExpandable(s.Args[n]) {
+					if not, end, arity, ok := emptyInList(buf.String(), s.SQL[i+1:]); ok {
+						buf.WriteString(nullRow(arity))
+						buf.WriteString(s.SQL[i+1 : i+1+end])
+						if not {
+							buf.WriteString(" IS NOT TRUE")
+						} else {
+							buf.WriteString(" IS TRUE")
+						}
+						n++
+						i += end
+						continue
+					}
+				}
+				buf.WriteString(exp)
+				args = append(args, xargs...)
+				n++
+				continue
+			}
+			buf.WriteByte(c)
+		case '`', '\'', '"':
+			end := strings.IndexByte(s.SQL[i+1:], c)
+			if end < 0 {
+				buf.WriteString(s.SQL[i:])
+				i = len(s.SQL)
+				continue
+			}
+			buf.WriteString(s.SQL[i : i+end+2])
+			i += end + 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	s.SQL = buf.String()
+	s.Args = append(args, s.Args[n:]...)
+	return s
+}
+
+// emptyInList reports whether the placeholder between before and after is
+// the list of an IN or NOT IN, returning the end of its closing parenthesis in
+// after and the number of columns of the row value it's compared with, e.g. 2
+// for "(a, b) IN (?)".
+func emptyInList(before, after string) (not bool, end, arity int, ok bool) {
+	before = strings.TrimRight(before, " \t\n")
+	if !strings.HasSuffix(before, "(") {
+		return
+	}
+	before = strings.TrimRight(strings.TrimSuffix(before, "("), " \t\n")
+	if !strings.HasSuffix(strings.ToUpper(before), "IN") {
+		return
+	}
+	before = before[:len(before)-len("IN")]
+	if before != "" && !strings.ContainsAny(before[len(before)-1:], " \t\n)`\"") {
+		return // e.g. MIN(?)
+	}
+	end = len(after) - len(strings.TrimLeft(after, " \t\n"))
+	if end == len(after) || after[end] != ')' {
+		return
+	}
+	before = strings.TrimRight(before, " \t\n")
+	if strings.HasSuffix(strings.ToUpper(before), "NOT") {
+		rest := before[:len(before)-len("NOT")]
+		if rest == "" || !isIdentByte(rest[len(rest)-1]) {
+			not = true
+			before = strings.TrimRight(rest, " \t\n")
+		}
+	}
+	return not, end + 1, rowArity(before), true
+}
+
+// rowArity returns the number of columns of the row value ending exp, e.g. 2
+// for "WHERE (a, b)", or 1 if it's not a row value, e.g. "WHERE LOWER(a)".
+func rowArity(exp string) int {
+	if !strings.HasSuffix(exp, ")") {
+		return 1
+	}
+	arity, depth := 1, 0
+	for i := len(exp) - 1; i >= 0; i-- {
+		switch c := exp[i]; c {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				if i > 0 && isIdentByte(exp[i-1]) {
+					return 1 // a function call
+				}
+				return arity
+			}
+		case ',':
+			if depth == 1 {
+				arity++
+			}
+		case '`', '\'', '"':
+			if j := strings.LastIndexByte(exp[:i], c); j >= 0 {
+				i = j
+			}
+		}
+	}
+	return 1
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// nullRow returns NULL, or a row of arity NULLs.
+func nullRow(arity int) string {
+	if arity == 1 {
+		return "NULL"
+	}
+	return "(" + strings.TrimSuffix(strings.Repeat("NULL, ", arity), ", ") + ")"
+}
+
+func expandArg(arg interface{}) (string, []interface{}) {
+	if !isExpandable(arg) {
+		return "?", []interface{}{arg}
+	}
+	v := reflect.ValueOf(arg)
+	if v.Len() == 0 {
+		return "NULL", nil
+	}
+	var exps []string
+	var args []interface{}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i).Interface()
+		if isExpandable(elem) {
+			exp, xargs := expandArg(elem)
+			exps = append(exps, "("+exp+")")
+			args = append(args, xargs...)
+			continue
+		}
+		exps = append(exps, "?")
+		args = append(args, elem)
+	}
+	return strings.Join(exps, ", "), args
+}
+
+func isExpandable(arg interface{}) bool {
+	if _, ok := arg.(driver.Valuer); ok || arg == nil {
+		return false
+	}
+	typ := reflect.TypeOf(arg)
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8
+}
+
+// Batches splits keys into batches binding at most size parameters, leaving
+// out the duplicates. A key that is a slice, i.e. a composite key, binds a
+// parameter for each element, and a batch holds one key at least.
+func Batches(keys []interface{}, size int) (batches [][]interface{}) {
+	var batch []interface{}
+	params := 0
+	seen := map[interface{}]bool{}
+	for _, key := range keys {
+		if key != nil && reflect.TypeOf(key).Comparable() {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		n := 1
+		if isExpandable(key) {
+			n = reflect.ValueOf(key).Len()
+		}
+		if size > 0 && len(batch) > 0 && params+n > size {
+			batches = append(batches, batch)
+			batch, params = nil, 0
+		}
+		batch = append(batch, key)
+		params += n
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return
+}
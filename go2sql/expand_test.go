@@ -0,0 +1,123 @@
+package go2sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+type valuer struct{}
+
+func (valuer) Value() (driver.Value, error) { return "v", nil }
+
+func TestExpand(t *testing.T) {
+	cases := []struct {
+		in   SQL
+		want SQL
+	}{
+		{NewSQL("WHERE a = ? AND b = ?", 1, "x"), NewSQL("WHERE a = ? AND b = ?", 1, "x")},
+		{NewSQL("WHERE id IN (?)", []int{1, 2, 3}), NewSQL("WHERE id IN (?, ?, ?)", 1, 2, 3)},
+		{NewSQL("WHERE a = ? AND id IN (?) AND b = ?", 0, []string{"x", "y"}, 4), NewSQL("WHERE a = ? AND id IN (?, ?) AND b = ?", 0, "x", "y", 4)},
+		{NewSQL("WHERE (a, b) IN (?)", [][]interface{}{{1, "x"}, {2, "y"}}), NewSQL("WHERE (a, b) IN ((?, ?), (?, ?))", 1, "x", 2, "y")},
+		{NewSQL("WHERE (a, b) IN (?)", []interface{}{[]interface{}{1, "x"}}), NewSQL("WHERE (a, b) IN ((?, ?))", 1, "x")},
+
+		// []byte, driver.Valuer and nil are single values
+		{NewSQL("WHERE a = ? AND b = ? AND c = ?", []byte("xy"), valuer{}, nil), NewSQL("WHERE a = ? AND b = ? AND c = ?", []byte("xy"), valuer{}, nil)},
+
+		// placeholders in quotes aren't expanded
+		{NewSQL("WHERE a = '?' AND `b?` IN (?)", []int{1, 2}), NewSQL("WHERE a = '?' AND `b?` IN (?, ?)", 1, 2)},
+		{NewSQL(`WHERE "a?" IN (?)`, []int{1}), NewSQL(`WHERE "a?" IN (?)`, 1)},
+
+		// the extra arguments are kept, and so are the extra placeholders
+		{NewSQL("WHERE a IN (?)", []int{1}, 2), NewSQL("WHERE a IN (?)", 1, 2)},
+		{NewSQL("WHERE a = ? AND b = ?", []int{1}), NewSQL("WHERE a = ? AND b = ?", 1)},
+
+		// empty lists are false, and empty NOT IN lists true
+		{NewSQL("WHERE id IN (?)", []int{}), NewSQL("WHERE id IN (NULL) IS TRUE")},
+		{NewSQL("WHERE id in( ? ) AND a = ?", []int{}, 1), NewSQL("WHERE id in( NULL ) IS TRUE AND a = ?", 1)},
+		{NewSQL("WHERE id NOT IN (?)", []int{}), NewSQL("WHERE id NOT IN (NULL) IS NOT TRUE")},
+		{NewSQL("WHERE NOT (`id` IN (?))", []int{}), NewSQL("WHERE NOT (`id` IN (NULL) IS TRUE)")},
+		{NewSQL("WHERE (a, b) IN (?)", [][]interface{}{}), NewSQL("WHERE (a, b) IN ((NULL, NULL)) IS TRUE")},
+		{NewSQL("WHERE (`a`, (b), 'c,d') NOT IN (?)", [][]interface{}{}), NewSQL("WHERE (`a`, (b), 'c,d') NOT IN ((NULL, NULL, NULL)) IS NOT TRUE")},
+		{NewSQL("WHERE LOWER(a, b) IN (?)", []string{}), NewSQL("WHERE LOWER(a, b) IN (NULL) IS TRUE")},
+		{NewSQL("WHERE cannot IN (?)", []int{}), NewSQL("WHERE cannot IN (NULL) IS TRUE")},
+
+		// elsewhere they're NULL
+		{NewSQL("VALUES (?)", []int{}), NewSQL("VALUES (NULL)")},
+		{NewSQL("SELECT MIN(?)", []int{}), NewSQL("SELECT MIN(NULL)")},
+		{NewSQL("WHERE a = ? OR b = 1", []int{}), NewSQL("WHERE a = NULL OR b = 1")},
+	}
+	for _, c := range cases {
+		got := c.in.Expand()
+		if got.SQL != c.want.SQL || !reflect.DeepEqual(got.Args, c.want.Args) {
+			t.Errorf("Expand(%q, %v) = %q, %v; want %q, %v", c.in.SQL, c.in.Args, got.SQL, got.Args, c.want.SQL, c.want.Args)
+		}
+	}
+}
+
+// TestExpandEmpty checks the empty lists match what they mean on SQLite.
+func TestExpandEmpty(t *testing.T) {
+	db := openSQLite(t)
+	if _, err := db.Exec("INSERT INTO rows (name) VALUES ('a'), ('b'), (NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		cond SQL
+		want int
+	}{
+		{NewSQL("name IN (?)", []string{}), 0},
+		{NewSQL("name NOT IN (?)", []string{}), 3},
+		{Not(NewSQL("name IN (?)", []string{})), 3},
+		{Or(NewSQL("name = ?", "a"), NewSQL("name IN (?)", []string{})), 1},
+		{NewSQL("name IN (?)", []string{"a", "b"}), 2},
+		{Not(NewSQL("name IN (?)", []string{"a"})), 1},
+		{NewSQL("(name, rowid) IN (?)", [][]interface{}{}), 0},
+		{NewSQL("(name, rowid) NOT IN (?)", [][]interface{}{}), 3},
+		{NewSQL("(name, rowid) IN (?)", [][]interface{}{{"a", 1}, {"b", 1}}), 1},
+	}
+	for _, c := range cases {
+		query := NewSQL("SELECT count(*) FROM rows WHERE "+c.cond.SQL, c.cond.Args...).Expand()
+		var n int
+		if err := db.QueryRow(Rebind(SQLite, query.SQL), query.Args...).Scan(&n); err != nil || n != c.want {
+			t.Errorf("%s = %d rows, %v; want %d", query.SQL, n, err, c.want)
+		}
+	}
+}
+
+func TestBatches(t *testing.T) {
+	pair := func(a, b int) interface{} { return []interface{}{a, b} }
+	cases := []struct {
+		keys []interface{}
+		size int
+		want [][]interface{}
+	}{
+		{nil, 2, nil},
+		{[]interface{}{1, 2, 3}, 0, [][]interface{}{{1, 2, 3}}},
+		{[]interface{}{1, 2, 3}, 2, [][]interface{}{{1, 2}, {3}}},
+		{[]interface{}{1, 2, 3, 4}, 2, [][]interface{}{{1, 2}, {3, 4}}},
+		{[]interface{}{1, 1, 2, 1, 3}, 2, [][]interface{}{{1, 2}, {3}}},
+		{[]interface{}{"a", nil, "a", nil}, 10, [][]interface{}{{"a", nil, nil}}},
+
+		// composite keys count a parameter for each column
+		{[]interface{}{pair(1, 2), pair(3, 4), pair(5, 6)}, 4, [][]interface{}{{pair(1, 2), pair(3, 4)}, {pair(5, 6)}}},
+		{[]interface{}{pair(1, 2), pair(3, 4)}, 3, [][]interface{}{{pair(1, 2)}, {pair(3, 4)}}},
+		// a key binding more than size parameters has a batch of its own
+		{[]interface{}{pair(1, 2), pair(3, 4)}, 1, [][]interface{}{{pair(1, 2)}, {pair(3, 4)}}},
+	}
+	for _, c := range cases {
+		if got := Batches(c.keys, c.size); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Batches(%v, %d) = %v; want %v", c.keys, c.size, got, c.want)
+		}
+	}
+
+	// 3 column keys stay under the 999 parameters of SQLite
+	var keys []interface{}
+	for i := 0; i < 1000; i++ {
+		keys = append(keys, []interface{}{i, i, i})
+	}
+	for _, batch := range Batches(keys, BatchSize) {
+		if query := NewSQL("(a, b, c) IN (?)", batch).Expand(); len(query.Args) > BatchSize {
+			t.Fatalf("batch of %d parameters; want %d at most", len(query.Args), BatchSize)
+		}
+	}
+}
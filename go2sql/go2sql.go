@@ -1,6 +1,9 @@
 package go2sql
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 type (
 	InsertOption interface {
@@ -31,7 +34,7 @@ type (
 		Full bool
 	}
 
-	sqldb struct{ *sql.DB }
+	sqldb struct{ Executor }
 )
 
 // var (
@@ -42,7 +45,7 @@ type (
 // )
 
 var (
-	DefaultDB *sqldb
+	DefaultDB Executor
 )
 
 func (Selects) QueryOption()  {}
@@ -76,12 +79,88 @@ func (SQL) DeleteOption() {}
 func (SQL) UpdateOption() {}
 func (SQL) QueryOption()  {}
 
-func SetDefaultDB(db *sql.DB) { DefaultDB = &sqldb{db} }
-func DB(db *sql.DB) sqldb     { return sqldb{db} }
-func (sqldb) InsertOption()   {}
-func (sqldb) DeleteOption()   {}
-func (sqldb) UpdateOption()   {}
-func (sqldb) QueryOption()    {}
+func SetDefaultDB(db Executor) { DefaultDB = db }
+
+// DB runs the queries on db, which is a *sql.DB, *sql.Tx, *sql.Conn or any
+// handle wrapping one of them.
+func DB(db Executor) sqldb  { return sqldb{db} }
+func (sqldb) InsertOption() {}
+func (sqldb) DeleteOption() {}
+func (sqldb) UpdateOption() {}
+func (sqldb) QueryOption()  {}
+
+// Executor runs the queries of the generated code, e.g. a *sql.DB, *sql.Tx
+// or *sql.Conn.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// TxBeginner begins the transactions of WithTx, e.g. a *sql.DB or *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxBeginnerOf returns db as the TxBeginner of the transactions the generated
+// code begins to save or delete the related rows, if it's a *sql.DB or
+// *sql.Conn. Other handles aren't, even if they embed one, as the queries of
+// the *sql.Tx would bypass them; their saves are run in a transaction by
+// WithTx and Tx instead.
+func TxBeginnerOf(db Executor) (TxBeginner, bool) {
+	switch db := db.(type) {
+	case *sql.DB:
+		return db, true
+	case *sql.Conn:
+		return db, true
+	}
+	return nil, false
+}
+
+type txOption struct{ *sql.Tx }
+
+// Tx runs the queries in the transaction tx, taking precedence over DB.
+func Tx(tx *sql.Tx) txOption   { return txOption{tx} }
+func (txOption) InsertOption() {}
+func (txOption) DeleteOption() {}
+func (txOption) UpdateOption() {}
+func (txOption) QueryOption()  {}
+
+// WithTx runs fn in a transaction of db, which is committed if fn returns
+// nil and rolled back otherwise.
+func WithTx(db TxBeginner, fn func(tx *sql.Tx) error) error {
+	return WithTxContext(context.Background(), db, fn)
+}
+
+// WithTxContext is WithTx beginning the transaction with ctx.
+func WithTxContext(ctx context.Context, db TxBeginner, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(tx)
+}
+
+type contextOption struct{ context.Context }
+
+// WithContext sets the context of the queries, which is passed on to the ones
+// of the related rows.
+func WithContext(ctx context.Context) contextOption { return contextOption{ctx} }
+func (contextOption) InsertOption()                 {}
+func (contextOption) DeleteOption()                 {}
+func (contextOption) UpdateOption()                 {}
+func (contextOption) QueryOption()                  {}
 
 func NewSQL(sql string, args ...interface{}) SQL {
 	return SQL{SQL: sql, Args: args}
@@ -215,37 +294,85 @@ func (opts QueryOptions) GetSQL() (sql SQL, ok bool) {
 	return
 }
 
-func (opts InsertOptions) GetDB() (*sql.DB, bool) {
+func (opts InsertOptions) GetDB() (Executor, bool) {
+	if tx, ok := opts.GetTx(); ok {
+		return tx, true
+	}
 	for _, o := range opts {
 		if db, ok := o.(sqldb); ok {
-			return db.DB, true
+			return db.Executor, true
 		}
 	}
 	return nil, false
 }
 
-func (opts DeleteOptions) GetDB() (*sql.DB, bool) {
+func (opts DeleteOptions) GetDB() (Executor, bool) {
+	if tx, ok := opts.GetTx(); ok {
+		return tx, true
+	}
 	for _, o := range opts {
 		if db, ok := o.(sqldb); ok {
-			return db.DB, true
+			return db.Executor, true
 		}
 	}
 	return nil, false
 }
 
-func (opts UpdateOptions) GetDB() (*sql.DB, bool) {
+func (opts UpdateOptions) GetDB() (Executor, bool) {
+	if tx, ok := opts.GetTx(); ok {
+		return tx, true
+	}
 	for _, o := range opts {
 		if db, ok := o.(sqldb); ok {
-			return db.DB, true
+			return db.Executor, true
 		}
 	}
 	return nil, false
 }
 
-func (opts QueryOptions) GetDB() (*sql.DB, bool) {
+func (opts QueryOptions) GetDB() (Executor, bool) {
+	if tx, ok := opts.GetTx(); ok {
+		return tx, true
+	}
 	for _, o := range opts {
 		if db, ok := o.(sqldb); ok {
-			return db.DB, true
+			return db.Executor, true
+		}
+	}
+	return nil, false
+}
+
+func (opts InsertOptions) GetTx() (*sql.Tx, bool) {
+	for _, o := range opts {
+		if tx, ok := o.(txOption); ok && tx.Tx != nil {
+			return tx.Tx, true
+		}
+	}
+	return nil, false
+}
+
+func (opts DeleteOptions) GetTx() (*sql.Tx, bool) {
+	for _, o := range opts {
+		if tx, ok := o.(txOption); ok && tx.Tx != nil {
+			return tx.Tx, true
+		}
+	}
+	return nil, false
+}
+
+func (opts UpdateOptions) GetTx() (*sql.Tx, bool) {
+	for _, o := range opts {
+		if tx, ok := o.(txOption); ok && tx.Tx != nil {
+			return tx.Tx, true
+		}
+	}
+	return nil, false
+}
+
+func (opts QueryOptions) GetTx() (*sql.Tx, bool) {
+	for _, o := range opts {
+		if tx, ok := o.(txOption); ok && tx.Tx != nil {
+			return tx.Tx, true
 		}
 	}
 	return nil, false
@@ -268,3 +395,75 @@ func (opts UpdateOptions) GetSelect() (sel Selects, ok bool) {
 	}
 	return
 }
+
+func (opts InsertOptions) GetDialect() Dialect {
+	for _, o := range opts {
+		if d, ok := o.(dialectOption); ok {
+			return d.Dialect
+		}
+	}
+	return DefaultDialect
+}
+
+func (opts DeleteOptions) GetDialect() Dialect {
+	for _, o := range opts {
+		if d, ok := o.(dialectOption); ok {
+			return d.Dialect
+		}
+	}
+	return DefaultDialect
+}
+
+func (opts UpdateOptions) GetDialect() Dialect {
+	for _, o := range opts {
+		if d, ok := o.(dialectOption); ok {
+			return d.Dialect
+		}
+	}
+	return DefaultDialect
+}
+
+func (opts QueryOptions) GetDialect() Dialect {
+	for _, o := range opts {
+		if d, ok := o.(dialectOption); ok {
+			return d.Dialect
+		}
+	}
+	return DefaultDialect
+}
+
+func (opts InsertOptions) GetContext() context.Context {
+	for _, o := range opts {
+		if ctx, ok := o.(contextOption); ok {
+			return ctx.Context
+		}
+	}
+	return context.Background()
+}
+
+func (opts DeleteOptions) GetContext() context.Context {
+	for _, o := range opts {
+		if ctx, ok := o.(contextOption); ok {
+			return ctx.Context
+		}
+	}
+	return context.Background()
+}
+
+func (opts UpdateOptions) GetContext() context.Context {
+	for _, o := range opts {
+		if ctx, ok := o.(contextOption); ok {
+			return ctx.Context
+		}
+	}
+	return context.Background()
+}
+
+func (opts QueryOptions) GetContext() context.Context {
+	for _, o := range opts {
+		if ctx, ok := o.(contextOption); ok {
+			return ctx.Context
+		}
+	}
+	return context.Background()
+}
@@ -0,0 +1,116 @@
+package go2sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openSQLite(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec("CREATE TABLE rows (name TEXT UNIQUE)"); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func countRows(t *testing.T, db *sql.DB) (n int) {
+	if err := db.QueryRow("SELECT count(*) FROM rows").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func insertRow(tx *sql.Tx, name string) error {
+	_, err := tx.Exec("INSERT INTO rows (name) VALUES (?)", name)
+	return err
+}
+
+func TestWithTx(t *testing.T) {
+	db := openSQLite(t)
+
+	if err := WithTx(db, func(tx *sql.Tx) error { return insertRow(tx, "a") }); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db); n != 1 {
+		t.Fatalf("%d rows after commit; want 1", n)
+	}
+
+	boom := errors.New("boom")
+	err := WithTx(db, func(tx *sql.Tx) error {
+		if err := insertRow(tx, "b"); err != nil {
+			return err
+		}
+		return boom
+	})
+	if err != boom {
+		t.Fatalf("WithTx = %v; want %v", err, boom)
+	}
+	if n := countRows(t, db); n != 1 {
+		t.Fatalf("%d rows after rollback; want 1", n)
+	}
+
+	func() {
+		defer func() {
+			if p := recover(); p != "panic" {
+				t.Fatalf("recovered %v; want the panic of fn", p)
+			}
+		}()
+		WithTx(db, func(tx *sql.Tx) error {
+			insertRow(tx, "c")
+			panic("panic")
+		})
+		t.Fatal("WithTx didn't panic")
+	}()
+	if n := countRows(t, db); n != 1 {
+		t.Fatalf("%d rows after panic; want 1", n)
+	}
+
+	// the connection is released by the rollbacks
+	if err := WithTx(db, func(tx *sql.Tx) error { return insertRow(tx, "d") }); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db); n != 2 {
+		t.Fatalf("%d rows after commit; want 2", n)
+	}
+}
+
+// loggedDB instruments the queries of the *sql.DB it embeds.
+type loggedDB struct{ *sql.DB }
+
+func TestTxBeginnerOf(t *testing.T) {
+	db := openSQLite(t)
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tx, err := conn.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	cases := []struct {
+		db   Executor
+		want bool
+	}{
+		{db, true},
+		{conn, true},
+		{tx, false},
+		{loggedDB{db}, false},
+	}
+	for _, c := range cases {
+		if b, ok := TxBeginnerOf(c.db); ok != c.want || (b != nil) != c.want {
+			t.Errorf("TxBeginnerOf(%T) = %v, %t; want %t", c.db, b, ok, c.want)
+		}
+	}
+}
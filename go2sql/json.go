@@ -0,0 +1,47 @@
+package go2sql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// JSONValue stores the value V points to as JSON in a column. It's used by
+// the generated code for the fields with the json option.
+type JSONValue struct {
+	V interface{}
+}
+
+// JSON wraps v, a pointer to a field, to scan and write it as JSON.
+func JSON(v interface{}) JSONValue { return JSONValue{V: v} }
+
+// Scan decodes the JSON of src into V, setting it to the zero value on NULL.
+func (j JSONValue) Scan(src interface{}) error {
+	if v := reflect.ValueOf(j.V); v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("go2sql: can't scan JSON into %T", j.V)
+	}
+	switch src := src.(type) {
+	case nil:
+		v := reflect.ValueOf(j.V).Elem()
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	case []byte:
+		return json.Unmarshal(src, j.V)
+	case string:
+		return json.Unmarshal([]byte(src), j.V)
+	}
+	return fmt.Errorf("go2sql: can't scan %T as JSON", src)
+}
+
+// Value encodes V as JSON, or NULL if it's nil.
+func (j JSONValue) Value() (driver.Value, error) {
+	data, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return string(data), nil
+}
@@ -0,0 +1,72 @@
+package go2sql
+
+import "testing"
+
+type jsonInfo struct {
+	Name string
+	Tags []string
+}
+
+func TestJSONValueScan(t *testing.T) {
+	cases := []struct {
+		src  interface{}
+		want string
+	}{
+		{`{"Name":"go","Tags":["a"]}`, "go"},
+		{[]byte(`{"Name":"rust"}`), "rust"},
+	}
+	for _, c := range cases {
+		var info jsonInfo
+		if err := JSON(&info).Scan(c.src); err != nil || info.Name != c.want {
+			t.Errorf("Scan(%#v) = %+v, %v; want %s", c.src, info, err, c.want)
+		}
+	}
+
+	info := jsonInfo{Name: "go", Tags: []string{"a"}}
+	if err := JSON(&info).Scan(nil); err != nil || info.Name != "" || info.Tags != nil {
+		t.Errorf("Scan(nil) = %+v, %v; want the zero value", info, err)
+	}
+
+	// a nil pointer field is allocated, and reset to nil by NULL
+	var ptr *jsonInfo
+	if err := JSON(&ptr).Scan(`{"Name":"go"}`); err != nil || ptr == nil || ptr.Name != "go" {
+		t.Errorf("Scan into nil pointer = %+v, %v", ptr, err)
+	}
+	if err := JSON(&ptr).Scan(nil); err != nil || ptr != nil {
+		t.Errorf("Scan(nil) into pointer = %+v, %v; want nil", ptr, err)
+	}
+
+	if err := JSON((*jsonInfo)(nil)).Scan(`{}`); err == nil {
+		t.Error("Scan into a nil target succeeded")
+	}
+	if err := JSON(&info).Scan(1); err == nil {
+		t.Error("Scan(1) succeeded")
+	}
+	if err := JSON(&info).Scan(`{`); err == nil {
+		t.Error("Scan of invalid JSON succeeded")
+	}
+}
+
+func TestJSONValueValue(t *testing.T) {
+	var nilPtr *jsonInfo
+	var nilMap map[string]int
+	cases := []struct {
+		v    interface{}
+		want interface{}
+	}{
+		{jsonInfo{Name: "go"}, `{"Name":"go","Tags":null}`},
+		{&jsonInfo{Tags: []string{}}, `{"Name":"","Tags":[]}`},
+		{nilPtr, nil},
+		{&nilPtr, nil},
+		{nilMap, nil},
+		{map[string]int{"a": 1}, `{"a":1}`},
+	}
+	for _, c := range cases {
+		if got, err := JSON(c.v).Value(); err != nil || got != c.want {
+			t.Errorf("Value(%#v) = %#v, %v; want %#v", c.v, got, err, c.want)
+		}
+	}
+	if _, err := JSON(func() {}).Value(); err == nil {
+		t.Error("Value of a func succeeded")
+	}
+}
@@ -0,0 +1,117 @@
+package go2sql
+
+import "strings"
+
+func (c Column) compare(op string, v interface{}) SQL {
+	return NewSQL(c.String()+" "+op+" ?", v)
+}
+
+// Eq returns the condition that the column equals v, or is NULL if v is nil.
+func (c Column) Eq(v interface{}) SQL {
+	if v == nil {
+		return c.IsNull()
+	}
+	return c.compare("=", v)
+}
+
+// Ne returns the condition that the column doesn't equal v, or is not NULL
+// if v is nil.
+func (c Column) Ne(v interface{}) SQL {
+	if v == nil {
+		return c.IsNotNull()
+	}
+	return c.compare("<>", v)
+}
+
+func (c Column) Lt(v interface{}) SQL    { return c.compare("<", v) }
+func (c Column) Le(v interface{}) SQL    { return c.compare("<=", v) }
+func (c Column) Gt(v interface{}) SQL    { return c.compare(">", v) }
+func (c Column) Ge(v interface{}) SQL    { return c.compare(">=", v) }
+func (c Column) Like(pattern string) SQL { return c.compare("LIKE", pattern) }
+
+// In returns the condition that the column is one of values, a slice
+// expanded by SQL.Expand.
+func (c Column) In(values interface{}) SQL { return NewSQL(c.String()+" IN (?)", values) }
+
+func (c Column) IsNull() SQL    { return NewSQL(c.String() + " IS NULL") }
+func (c Column) IsNotNull() SQL { return NewSQL(c.String() + " IS NOT NULL") }
+
+func (c Column) Asc() SQL  { return NewSQL(c.String() + " ASC") }
+func (c Column) Desc() SQL { return NewSQL(c.String() + " DESC") }
+
+// And joins the conditions by AND.
+func And(conds ...SQL) SQL { return joinConds(" AND ", conds) }
+
+// Or joins the conditions by OR.
+func Or(conds ...SQL) SQL { return joinConds(" OR ", conds) }
+
+// Not negates the condition.
+func Not(cond SQL) SQL { return NewSQL("NOT ("+cond.SQL+")", cond.Args...) }
+
+func joinConds(sep string, conds []SQL) SQL {
+	var s SQL
+	var parts []string
+	for _, c := range conds {
+		parts = append(parts, "("+c.SQL+")")
+		s.Args = append(s.Args, c.Args...)
+	}
+	s.SQL = strings.Join(parts, sep)
+	return s
+}
+
+// Query holds the fragments composed by the generated query builders, e.g.
+// LanguageQuery().Where(LanguageName.Eq("Go")).OrderBy(LanguageID.Desc()).
+type Query struct {
+	Joins   []SQL
+	Wheres  []SQL
+	Orders  []SQL
+	Limit   *int // no limit if nil
+	Offset  int
+	Options []QueryOption
+}
+
+// Build returns the sql following the SELECT ... FROM of the query, in the
+// order of the joins, conditions, orders and the limit of the dialect.
+func (q Query) Build(d Dialect) SQL {
+	var parts []string
+	var args []interface{}
+	for _, j := range q.Joins {
+		parts = append(parts, j.SQL)
+		args = append(args, j.Args...)
+	}
+	if len(q.Wheres) > 0 {
+		where := And(q.Wheres...)
+		if len(q.Wheres) == 1 {
+			where = q.Wheres[0]
+		}
+		parts = append(parts, "WHERE "+where.SQL)
+		args = append(args, where.Args...)
+	}
+	if len(q.Orders) > 0 {
+		var orders []string
+		for _, o := range q.Orders {
+			orders = append(orders, o.SQL)
+			args = append(args, o.Args...)
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(orders, ", "))
+	}
+	limit := -1
+	if q.Limit != nil {
+		limit = *q.Limit
+	}
+	if l := d.Limit(limit, q.Offset); l != "" {
+		parts = append(parts, l)
+	}
+	return NewSQL(strings.Join(parts, " "), args...)
+}
+
+// QueryOptions returns the options of the query preceded by its sql, built
+// for the dialect of the options. The options can't hold an SQL, which the
+// built one would override.
+func (q Query) QueryOptions() ([]QueryOption, error) {
+	if _, ok := QueryOptions(q.Options).GetSQL(); ok {
+		return nil, ErrQuerySQL
+	}
+	d := QueryOptions(q.Options).GetDialect()
+	return append([]QueryOption{q.Build(d)}, q.Options...), nil
+}
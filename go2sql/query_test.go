@@ -0,0 +1,78 @@
+package go2sql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var (
+	testName = Column{Table: "languages", Name: "name"}
+	testID   = Column{Table: "languages", Name: "id"}
+)
+
+func limit(n int) *int { return &n }
+
+func TestQueryBuild(t *testing.T) {
+	cases := []struct {
+		d     Dialect
+		query Query
+		sql   string
+		args  []interface{}
+	}{
+		{MySQL, Query{}, "", nil},
+		{MySQL, Query{Wheres: []SQL{testName.Eq("Go")}}, "WHERE `languages`.`name` = ?", []interface{}{"Go"}},
+		{MySQL, Query{Wheres: []SQL{testName.Eq("Go"), testID.Gt(1)}}, "WHERE (`languages`.`name` = ?) AND (`languages`.`id` > ?)", []interface{}{"Go", 1}},
+		{
+			MySQL,
+			Query{
+				Joins:  []SQL{NewSQL("INNER JOIN `keywords` ON `keywords`.`language_id` = `languages`.`id` AND `keywords`.`type` = ?", "k")},
+				Wheres: []SQL{Or(testName.Eq(nil), testName.Like("R%")), testID.In([]int{1, 2})},
+				Orders: []SQL{testID.Desc(), testName.Asc()},
+				Limit:  limit(10),
+				Offset: 20,
+			},
+			"INNER JOIN `keywords` ON `keywords`.`language_id` = `languages`.`id` AND `keywords`.`type` = ? " +
+				"WHERE ((`languages`.`name` IS NULL) OR (`languages`.`name` LIKE ?)) AND (`languages`.`id` IN (?)) " +
+				"ORDER BY `languages`.`id` DESC, `languages`.`name` ASC LIMIT 10 OFFSET 20",
+			[]interface{}{"k", "R%", []int{1, 2}},
+		},
+		{MySQL, Query{Wheres: []SQL{Not(testName.Ne(nil)), testName.Ne("C")}}, "WHERE (NOT (`languages`.`name` IS NOT NULL)) AND (`languages`.`name` <> ?)", []interface{}{"C"}},
+		{MySQL, Query{Wheres: []SQL{testID.Lt(1), testID.Le(2), testID.Ge(3)}}, "WHERE (`languages`.`id` < ?) AND (`languages`.`id` <= ?) AND (`languages`.`id` >= ?)", []interface{}{1, 2, 3}},
+
+		// limits
+		{MySQL, Query{Limit: limit(0)}, "LIMIT 0", nil},
+		{MySQL, Query{Limit: limit(-1)}, "", nil},
+		{MySQL, Query{Offset: 5}, "LIMIT 18446744073709551615 OFFSET 5", nil},
+		{PostgreSQL, Query{Offset: 5}, "LIMIT ALL OFFSET 5", nil},
+		{SQLite, Query{Offset: 5}, "LIMIT -1 OFFSET 5", nil},
+		{PostgreSQL, Query{Orders: []SQL{testID.Asc()}, Limit: limit(1)}, "ORDER BY `languages`.`id` ASC LIMIT 1", nil},
+	}
+	for _, c := range cases {
+		got := c.query.Build(c.d)
+		if got.SQL != c.sql || !reflect.DeepEqual(got.Args, c.args) {
+			t.Errorf("%T Build(%+v) = %q, %v; want %q, %v", c.d, c.query, got.SQL, got.Args, c.sql, c.args)
+		}
+	}
+}
+
+func TestQueryOptions(t *testing.T) {
+	tables := Tables{{Name: "keywords"}}
+	q := Query{Wheres: []SQL{testID.Eq(1)}, Limit: limit(0), Options: []QueryOption{WithDialect(PostgreSQL), tables}}
+	opts, err := q.QueryOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, _ := QueryOptions(opts).GetSQL()
+	if want := NewSQL("WHERE `languages`.`id` = ? LIMIT 0", 1); !reflect.DeepEqual(sql, want) {
+		t.Errorf("sql = %+v; want %+v", sql, want)
+	}
+	if got, _ := QueryOptions(opts).GetTables(); !reflect.DeepEqual(got, tables) || QueryOptions(opts).GetDialect() != PostgreSQL {
+		t.Errorf("options = %v", opts)
+	}
+
+	q.Options = append(q.Options, NewSQL("WHERE 1 = 1"))
+	if _, err := q.QueryOptions(); !errors.Is(err, ErrQuerySQL) {
+		t.Errorf("QueryOptions with an SQL = %v; want %v", err, ErrQuerySQL)
+	}
+}
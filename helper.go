@@ -1,6 +1,11 @@
 package main
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"bitbucket.org/pkg/inflect"
+)
 
 func toSnake(str string) string {
 	if len(str) <= 1 {
@@ -35,3 +40,34 @@ func isUpper(l rune) bool {
 func camelCase(str string) string {
 	return strings.ToLower(str[:1]) + str[1:]
 }
+
+// varName lower cases the leading initialism of str too, e.g. id for ID and
+// htmlName for HTMLName.
+func varName(str string) string {
+	runes := []rune(str)
+	i := 0
+	for i < len(runes) && isUpper(runes[i]) {
+		i++
+	}
+	if i > 1 && i < len(runes) {
+		i--
+	}
+	return strings.ToLower(string(runes[:i])) + string(runes[i:])
+}
+
+// pluralize keeps the case of the first letter, which inflect.Pluralize loses
+// for irregular words like Person.
+func pluralize(str string) string {
+	plural := inflect.Pluralize(str)
+	if isUpper(rune(str[0])) {
+		return strings.ToUpper(plural[:1]) + plural[1:]
+	}
+	return plural
+}
+
+func printWithLineNo(src string) {
+	codes := strings.Split(src, "\n")
+	for i, line := range codes {
+		fmt.Printf("%3d: %s\n", i+1, line)
+	}
+}
@@ -18,3 +18,17 @@ func TestToSnake(t *testing.T) {
 		}
 	}
 }
+
+func TestPluralize(t *testing.T) {
+	cases := [][2]string{
+		{"Language", "Languages"},
+		{"Person", "People"},
+		{"person", "people"},
+		{"l", "ls"},
+	}
+	for _, c := range cases {
+		if got, want := pluralize(c[0]), c[1]; got != want {
+			t.Errorf("pluralize(%s) = %s; want %s", c[0], got, want)
+		}
+	}
+}
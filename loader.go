@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+type Package struct {
+	Name  string
+	Dir   string
+	Fset  *token.FileSet
+	Files []*ast.File
+	Info  types.Info
+}
+
+// loadPackage parses and type checks the package found by import path or
+// directory with the go command, so it works the same inside and outside of
+// modules and only includes the files matching the build tags. The code of
+// the files generated by go2sql is left out.
+func loadPackage(path string, tags ...string) (*Package, error) {
+	conf := packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		ParseFile: parseFile,
+	}
+	if len(tags) > 0 {
+		conf.BuildFlags = []string{"-tags", strings.Join(tags, ",")}
+	}
+	pkgs, err := packages.Load(&conf, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%s matches %d packages", path, len(pkgs))
+	}
+	lpkg := pkgs[0]
+
+	// hand-written code might refer to the generated code that is left out,
+	// so type errors are not fatal.
+	for _, err := range lpkg.Errors {
+		if err.Kind != packages.TypeError {
+			return nil, err
+		}
+		if cliFlags.debug {
+			log.Println(err)
+		}
+	}
+	if len(lpkg.GoFiles) == 0 {
+		return nil, fmt.Errorf("no go files found in %s", path)
+	}
+
+	return &Package{
+		Name:  lpkg.Name,
+		Dir:   filepath.Dir(lpkg.GoFiles[0]),
+		Fset:  lpkg.Fset,
+		Files: lpkg.Syntax,
+		Info:  *lpkg.TypesInfo,
+	}, nil
+}
+
+// parseFile parses only the package clause of the files generated by go2sql.
+func parseFile(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	mode := parser.ParseComments | parser.AllErrors
+	if isGo2SQLFile(filename) {
+		mode = parser.PackageClauseOnly
+	}
+	return parser.ParseFile(fset, filename, src, mode)
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestLoadPackageTags(t *testing.T) {
+	for _, c := range []struct {
+		tags  []string
+		found bool
+	}{
+		{nil, false},
+		{[]string{"go2sql_tagged"}, true},
+	} {
+		pkg, err := loadPackage("./testdata/model", c.tags...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := NewParser(pkg.Name)
+		if err := p.Parse(pkg.Info); err != nil {
+			t.Fatal(err)
+		}
+		if _, found := p.Tables["Tagged"]; found != c.found {
+			t.Errorf("tags %v: Tagged found = %t; want %t", c.tags, found, c.found)
+		}
+	}
+}
@@ -1,74 +1,190 @@
 package main
 
 import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
+	"text/template"
 )
 
 func main() {
-	// var pkgStr string
-	// flag.StringVar(&pkgStr, "pkg", "", "import path")
-	// flag.Parse()
-	// args := flag.Args()
-
-	// if pkgStr == "" {
-	// 	// TODO: use pwd as default import path
-	// 	exitf("need to specify package import path")
-	// }
-	// if len(args) == 0 {
-	// 	exitf("need to specify types")
-	// }
-
-	// // log.Printf("--> %+v\n", typ)
-
-	// fs := token.NewFileSet()
-	// pkgs, err := parser.ParseDir(fs, filepath.Join(os.Getenv("GOPATH"), "src", pkgStr), ignoreGo2SQLFiles, parser.ParseComments|parser.DeclarationErrors|parser.AllErrors)
-	// if err != nil {
-	// 	exitf(err.Error())
-	// }
-
-	// var pkg *ast.Package
-	// for _, pkg = range pkgs {
-	// }
-	// // v := visitor{typ: args[0]}
-	// // v := newVisitor()
-	// // ast.Walk(v, pkg)
-	// // printutils.PrettyPrint(v.tables["Language"].GetColumn("Author"))
-	// // log.Printf("--> %+v\n", v.consts)
-	// // printutils.PrettyPrint(v.tables)
-
-	// // for _, f := range v.struc.Fields.List {
-	// // 	switch typ := f.Type.(type) {
-	// // 	case *ast.Ident:
-	// // 		log.Printf("--> %+v\n", typ.Name)
-	// // 	case *ast.ArrayType:
-	// // 		log.Printf("--> %+v\n", typ.Elt)
-	// // 	}
-	// // }
-
-	// // pkg, err := types.Check(filepath.Join(os.Getenv("GOPATH"), "src", pkgStr), fs, files)
-	// // if err != nil {
-	// // 	exitf(err.Error())
-	// // }
-	// // typ := args[0]
-	// // log.Printf("--> %+v\n", typ)
-	// // // o := pkg.Scope().Lookup(typ)
-	// // log.Printf("--> %+v\n", pkg.Complete())
-	// // log.Printf("--> %+v\n", pkg.Scope().Names())
+	var pkgStr, tmplDir, funcsStr, tagsStr string
+	var force, strict bool
+	flag.StringVar(&pkgStr, "pkg", ".", "import path or directory of the package containing the types")
+	flag.StringVar(&tmplDir, "templates", "", "directory of *.tmpl files overriding or extending the built-in templates")
+	flag.StringVar(&funcsStr, "funcs", "", "comma separated function templates to render for the types without a go2sql:funcs directive (default all)")
+	flag.StringVar(&tagsStr, "tags", "", "comma separated build tags for loading the package")
+	flag.BoolVar(&force, "force", false, "rewrite the files even if they are up to date")
+	flag.BoolVar(&strict, "strict", false, "treat warnings as errors")
+	flag.BoolVar(&cliFlags.debug, "debug", false, "print type check errors and unformatted code")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: go2sql [check] [flags] Type [Type...]\n\n")
+		fmt.Fprintf(os.Stderr, "check compares the generated files with the types without writing them,\n")
+		fmt.Fprintf(os.Stderr, "printing the differences and exiting with status 1 if any is stale.\n\n")
+		flag.PrintDefaults()
+	}
+	args := os.Args[1:]
+	check := len(args) > 0 && args[0] == "check"
+	if check {
+		args = args[1:]
+	}
+	flag.CommandLine.Parse(args)
+	typs := flag.Args()
+
+	if len(typs) == 0 {
+		flag.Usage()
+		exitf("need to specify types")
+	}
+
+	templates := tmpl
+	if tmplDir != "" {
+		var err error
+		if templates, err = loadTemplates(tmplDir); err != nil {
+			exitf("failed to load templates: %s", err)
+		}
+	}
+
+	var tags []string
+	if tagsStr != "" {
+		tags = strings.Split(tagsStr, ",")
+	}
+	pkg, err := loadPackage(pkgStr, tags...)
+	if err != nil {
+		exitf("failed to load package %s: %s", pkgStr, err)
+	}
+
+	p := NewParser(pkg.Name)
+	p.Fset = pkg.Fset
+	if err := p.Parse(pkg.Info); err != nil {
+		exitf("failed to parse package %s: %s", pkgStr, err)
+	}
+	if err := p.ParseDirectives(pkg.Files); err != nil {
+		exitf("failed to parse package %s: %s", pkgStr, err)
+	}
+
+	var failed bool
+	for _, d := range p.TableDiagnostics(typs) {
+		fmt.Fprintln(os.Stderr, d)
+		failed = failed || d.Severity == SeverityError || strict
+	}
+	if failed {
+		exitf("found errors in package %s", pkgStr)
+	}
+
+	defaults := DefaultFunctions
+	if funcsStr != "" {
+		defaults = strings.Split(funcsStr, ",")
+	}
+	funcs := p.Functions(typs, defaults)
+
+	var stale bool
+	for _, typ := range typs {
+		table, ok := p.Tables[typ]
+		if !ok {
+			exitf("can't find struct %s in package %s", typ, pkgStr)
+		}
+		table.Package = pkg.Name
+
+		filename := filepath.Join(pkg.Dir, go2sqlFileName(typ))
+		if check {
+			src, err := table.Generate(templates, funcs[typ])
+			if err != nil {
+				if cliFlags.debug {
+					printWithLineNo(table.w.String())
+				}
+				exitf("failed to generate %s: %s", typ, err)
+			}
+			if diff := diffGenerated(filename, src); diff != "" {
+				fmt.Print(diff)
+				stale = true
+			}
+			continue
+		}
+
+		written, err := writeGenerated(table, templates, funcs[typ], filename, force)
+		if err != nil {
+			if cliFlags.debug {
+				printWithLineNo(table.w.String())
+			}
+			exitf("failed to generate %s: %s", typ, err)
+		}
+		if !written && cliFlags.debug {
+			log.Printf("%s is up to date", filename)
+		}
+	}
+	if stale {
+		exitf("generated files are out of date, run go2sql without check to regenerate them")
+	}
+}
+
+// writeGenerated generates the file of table and writes it to filename,
+// unless the hash on the header of the file shows it's generated from the
+// same inputs, and reports whether it's written. force rewrites it anyway.
+func writeGenerated(table *Table, templates *template.Template, funcs []string, filename string, force bool) (bool, error) {
+	if !force && generatedHash(filename) == table.InputHash(templates, funcs) {
+		return false, nil
+	}
+	src, err := table.Generate(templates, funcs)
+	if err != nil {
+		return false, err
+	}
+	if err := ioutil.WriteFile(filename, src, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-func ignoreGo2SQLFiles(fi os.FileInfo) bool {
-	if fi.IsDir() {
-		return true
+// generatedHash returns the hash stamped on the header of the generated file,
+// or an empty string if the file doesn't exist or has no hash.
+func generatedHash(filename string) string {
+	f, err := os.Open(filename)
+	if err != nil {
+		return ""
 	}
-	if strings.HasSuffix(fi.Name(), Go2SQLFileSuffix+".go") || strings.HasSuffix(fi.Name(), Go2SQLFileSuffix+"_test.go") {
-		return false
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, HashCommentPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, HashCommentPrefix))
+		}
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+	}
+	return ""
+}
+
+// diffGenerated returns the unified diff from the formatted file on disk to
+// the generated src, or an empty string if the file is up to date.
+func diffGenerated(filename string, src []byte) string {
+	old, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		exitf("failed to read %s: %s", filename, err)
+	}
+	if formatted, err := format.Source(old); err == nil {
+		old = formatted
 	}
-	return true
+	return unifiedDiff(filename, filename+" (generated)", old, src)
+}
+
+func go2sqlFileName(typ string) string {
+	return toSnake(typ) + "_" + Go2SQLFileSuffix + ".go"
+}
+
+// isGo2SQLFile reports whether filename is generated by go2sql.
+func isGo2SQLFile(filename string) bool {
+	return strings.HasSuffix(filename, Go2SQLFileSuffix+".go") || strings.HasSuffix(filename, Go2SQLFileSuffix+"_test.go")
 }
 
-func exitf(fmt string, args ...interface{}) {
-	log.Printf("need to specify package import path"+"\n", args...)
+func exitf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "go2sql: "+format+"\n", args...)
 	os.Exit(1)
 }
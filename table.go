@@ -2,10 +2,14 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"go/ast"
+	"go/format"
+	"go/token"
 	"go/types"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -16,11 +20,21 @@ const (
 	FlagPK     = "primary-key"
 	FlagInline = "inline"
 	FlagIgnore = "-"
+	FlagJSON   = "json"
 
-	FlagPrefix = "prefix:"
+	FlagPrefix     = "prefix:"
+	FlagForeignKey = "foreign-key:"
+	FlagReferences = "references:"
+	FlagJoinTable  = "join-table:"
+	FlagJoinFK     = "join-fk:"
+	FlagJoinRef    = "join-ref:"
 
 	TableNameSuffix  = "TableName"
 	Go2SQLFileSuffix = "go2sql"
+
+	// HashCommentPrefix starts the line of the generated files holding the
+	// hash of their generator inputs.
+	HashCommentPrefix = "// go2sql hash "
 )
 
 var cliFlags = struct {
@@ -31,8 +45,75 @@ func init() {
 	log.SetFlags(log.Lshortfile)
 }
 
+// Option holds the per struct generator options set by directives.
 type Option struct {
-	Functions []string
+	Functions []string // function templates to render, set by //go2sql:funcs
+}
+
+var DefaultFunctions = []string{
+	"is_empty_row", "is_new_row",
+	"find", "find_many",
+	"insert", "insert_many",
+	"update", "update_many",
+	"delete", "delete_many",
+	"fetch",
+	"query",
+}
+
+// functionDeps lists the function templates the generated code of a function
+// template calls.
+var functionDeps = map[string][]string{
+	"find":        {"fetch"},
+	"find_many":   {"fetch"},
+	"insert":      {"is_new_row"},
+	"insert_many": {"is_new_row"},
+	"update":      {"insert"},
+	"update_many": {"update"},
+	"delete":      {"is_new_row"},
+	"delete_many": {"is_new_row"},
+	"query":       {"find", "find_many"},
+}
+
+// relatedFunctionDeps lists the function templates of the related types the
+// generated code of a function template calls, by the relationship, e.g. the
+// Update of the has-many rows saved by Insert.
+var relatedFunctionDeps = map[string]map[Relationship][]string{
+	"fetch": {
+		RelationshipBelongsTo:  {"find_many"},
+		RelationshipHasOne:     {"find_many"},
+		RelationshipHasMany:    {"find_many"},
+		RelationshipManyToMany: {"find_many"},
+	},
+	"insert": {
+		RelationshipBelongsTo:  {"is_empty_row", "insert"},
+		RelationshipHasOne:     {"is_empty_row", "update"},
+		RelationshipHasMany:    {"update_many"},
+		RelationshipManyToMany: {"update_many"},
+	},
+	"insert_many": {
+		RelationshipBelongsTo:  {"is_empty_row", "insert_many"},
+		RelationshipHasOne:     {"is_empty_row", "update_many"},
+		RelationshipHasMany:    {"update_many"},
+		RelationshipManyToMany: {"update_many"},
+	},
+	"update": {
+		RelationshipBelongsTo:  {"is_empty_row", "update"},
+		RelationshipHasOne:     {"is_empty_row", "update"},
+		RelationshipHasMany:    {"update_many"},
+		RelationshipManyToMany: {"update_many"},
+	},
+	"delete": {
+		RelationshipBelongsTo:  {"delete"},
+		RelationshipHasOne:     {"delete"},
+		RelationshipHasMany:    {"delete_many"},
+		RelationshipManyToMany: {"delete_many"},
+	},
+	"delete_many": {
+		RelationshipBelongsTo:  {"is_empty_row", "delete_many"},
+		RelationshipHasOne:     {"is_empty_row", "delete_many"},
+		RelationshipHasMany:    {"delete_many"},
+		RelationshipManyToMany: {"delete_many"},
+	},
 }
 
 type Function struct {
@@ -40,17 +121,22 @@ type Function struct {
 	Template template.Template
 }
 
+// Table is the data passed to the templates for each struct. Its exported
+// fields and methods, together with those of Column, are the data model
+// custom templates can rely on.
 type Table struct {
-	Package string
+	Package string // package name of the generated file
 
 	struc       *ast.StructType
-	Name        string
-	ColName     string
-	SQLName     string
-	RefName     string
-	ColRefName  string
-	VarName     string
-	ColVarName  string
+	typ         types.Type
+	pos         token.Pos
+	Name        string // struct name, e.g. Language
+	ColName     string // collection type name, e.g. Languages
+	SQLName     string // table name, e.g. languages
+	RefName     string // receiver name, e.g. l
+	ColRefName  string // collection receiver name, e.g. ls
+	VarName     string // variable name, e.g. language
+	ColVarName  string // collection variable name, e.g. languages
 	IDColumn    *Column
 	Columns     []*Column
 	PrimaryKeys []*Column
@@ -62,55 +148,97 @@ type Table struct {
 
 	HasCustomSQLName bool
 
+	Option Option
+
+	Hash    string   // hash of the generator inputs, see InputHash
+	Imports []string // import specs of the packages of the column types, e.g. "time"
+
 	w bytes.Buffer
 }
 
+// Column is a struct field, which is either a sql column or, when IsTable is
+// true, a relationship to another table.
 type Column struct {
 	field        *types.Var
-	Name         string
-	SQLName      string
+	Name         string // field name, prefixed by the struct field names if inlined, e.g. InfoCreatedAt
+	Field        string // field selector, e.g. Info.CreatedAt
+	SQLName      string // column name, or the table name used in go2sql.Tables
 	IsPrimaryKey bool
 	Relationship Relationship
 
-	Type      string
-	TableType string
+	Type      string // field type as written in the package
+	TableType string // struct name of the related table
 	IsPointer bool
+	IsJSON    bool // stored as JSON by the json option
+	IsArray   bool // slice of a basic type stored as an array
+
+	ForeignKey string // foreign key field set by foreign-key:
+	References string // referenced key field set by references:
+	JoinTable  string // many-to-many join table set by join-table:
+	JoinFK     string // join table column referencing the host set by join-fk:
+	JoinRef    string // join table column referencing the guest set by join-ref:
 
-	flags []string
+	declared Relationship // relationship set by the tag
+	depth    int          // embedding level of the field
+	pos      token.Pos    // position of the field in the table struct
+	flags    []string
+	joinKeys []JoinKey
 
 	IsTable   bool
-	Table     *Table
-	TypeTable *Table
+	Table     *Table // table holding the column
+	TypeTable *Table // related table
+
+	Op string // operation of the generated function, set by WithOp
 
 	parser *Parser
 }
 
+// WithOp returns a copy of the column with Op set to op, e.g. for the errors
+// of the templates shared by Insert and Update like save_has.
+func (c *Column) WithOp(op string) *Column {
+	cp := *c
+	cp.Op = op
+	return &cp
+}
+
 func (c *Column) ExpIsZero() string {
+	if c.IsTable {
+		switch c.Relationship {
+		case RelationshipBelongsTo, RelationshipHasOne:
+			return fmt.Sprintf("%s.%s.IsEmptyRow()", c.Table.RefName, c.Name)
+		case RelationshipHasMany, RelationshipManyToMany:
+			return fmt.Sprintf("len(%s.%s) == 0", c.Table.RefName, c.Name)
+		}
+		return ""
+	}
+
+	if null := c.ExpIsNull(c.Table.RefName); null != "" {
+		return null
+	}
+
 	ftype := c.field.Type()
 
 typeSwitch:
 	switch typ := ftype.(type) {
 	case *types.Named:
+		if isTimeType(typ) {
+			return fmt.Sprintf("%s.%s.IsZero()", c.Table.RefName, c.Field)
+		}
 		ftype = typ.Underlying()
 		goto typeSwitch
 	case *types.Basic:
 		info := typ.Info()
 		if info&types.IsBoolean != 0 {
-			return fmt.Sprintf("!%s.%s", c.Table.RefName, c.Name)
+			return fmt.Sprintf("!%s.%s", c.Table.RefName, c.Field)
 		} else if info&types.IsNumeric != 0 {
-			log.Println(c.Name)
-			// printutils.PrettyPrint(c)
-			return fmt.Sprintf("%s.%s == 0", c.Table.RefName, c.Name)
+			return fmt.Sprintf("%s.%s == 0", c.Table.RefName, c.Field)
 		} else if info&types.IsString != 0 {
-			return fmt.Sprintf(`%s.%s == ""`, c.Table.RefName, c.Name)
+			return fmt.Sprintf(`%s.%s == ""`, c.Table.RefName, c.Field)
 		}
-	case *types.Pointer, *types.Slice:
-		return fmt.Sprintf("%s.%s == nil", c.Table.RefName, c.Name)
+	case *types.Slice, *types.Map:
+		return fmt.Sprintf("len(%s.%s) == 0", c.Table.RefName, c.Field)
 	case *types.Struct:
 		// TODO
-
-	// case *types.Slice:
-	// 	return fmt.Sprintf("%s.%s == nil", c.Table.RefName, c.Name)
 	case *types.Array:
 	}
 
@@ -141,12 +269,40 @@ func (t *Table) ExpIsNewRow() string {
 	return strings.Join(cs, " &&\n")
 }
 
+// HasNaturalKeys reports whether the primary keys are set by the application
+// rather than generated by the database, i.e. there is no id column. Rows of
+// such tables are always inserted, and updated by upserts.
+func (t *Table) HasNaturalKeys() bool {
+	return t.IDColumn == nil && len(t.PrimaryKeys) > 0
+}
+
 func (t *Table) ExpPrimaryKeyValues() string {
 	var exps []string
 	for _, pk := range t.PrimaryKeys {
-		exps = append(exps, t.RefName+"."+pk.Name)
+		exps = append(exps, t.RefName+"."+pk.Field)
 	}
-	return strings.Join(exps, ",")
+	return strings.Join(exps, ", ")
+}
+
+// ExpPrimaryKeySQL returns the primary key column, or a row value of all the
+// primary key columns, for use in sql IN conditions.
+func (t *Table) ExpPrimaryKeySQL() string {
+	return sqlTuple(columnSQLNames(t.PrimaryKeys))
+}
+
+func (t *Table) ExpPrimaryKeyPlaceholder() string {
+	return sqlTuple(placeholders(len(t.PrimaryKeys)))
+}
+
+// ExpPrimaryKeyArg returns the primary key of the row, or a slice of all the
+// primary keys, as an argument of the IN conditions expanded by
+// go2sql.SQL.Expand.
+func (t *Table) ExpPrimaryKeyArg() string {
+	var exps []string
+	for _, pk := range t.PrimaryKeys {
+		exps = append(exps, t.RefName+"."+pk.Field)
+	}
+	return argTuple(exps)
 }
 
 type Relationship int
@@ -194,6 +350,17 @@ func (t *Table) GetColumn(c string) *Column {
 	return nil
 }
 
+// flagValue returns the value of the flag of prefix, e.g. OwnerID of
+// foreign-key:OwnerID.
+func flagValue(flags []string, prefix string) (string, bool) {
+	for _, fl := range flags {
+		if strings.HasPrefix(fl, prefix) {
+			return strings.TrimPrefix(fl, prefix), true
+		}
+	}
+	return "", false
+}
+
 func contains(flags []string, f string) bool {
 	for _, fl := range flags {
 		if fl == f {
@@ -203,7 +370,132 @@ func contains(flags []string, f string) bool {
 	return false
 }
 
-// var fileHeader = template.Must(template.New("").Parse(``))
+// Functions returns the function templates to render for t, which are the ones
+// set by the go2sql:funcs directive or defaults, along with the ones they
+// depend on.
+func (t *Table) Functions(defaults []string) []string {
+	names := t.Option.Functions
+	if len(names) == 0 {
+		names = defaults
+	}
+	return withFunctionDeps(names)
+}
+
+// Functions returns the function templates to render for each of typs, see
+// Table.Functions, along with the ones the generated code of the others calls
+// on them, e.g. update_many of Keyword for insert of Language, which saves
+// its has-many Keywords by Keywords.Update.
+func (p *Parser) Functions(typs []string, defaults []string) map[string][]string {
+	funcs := make(map[string][]string)
+	for _, typ := range typs {
+		if table, ok := p.Tables[typ]; ok {
+			funcs[typ] = table.Functions(defaults)
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, typ := range typs {
+			table, ok := p.Tables[typ]
+			if !ok {
+				continue
+			}
+			for _, c := range table.TableColumns() {
+				guest, ok := funcs[c.TypeTable.Name]
+				if !ok {
+					continue
+				}
+				for _, fn := range funcs[typ] {
+					for _, dep := range relatedFunctionDeps[fn][c.Relationship] {
+						if !contains(guest, dep) {
+							guest = withFunctionDeps(append(guest, dep))
+							changed = true
+						}
+					}
+				}
+				funcs[c.TypeTable.Name] = guest
+			}
+		}
+	}
+	return funcs
+}
+
+// withFunctionDeps returns names along with the function templates they
+// depend on.
+func withFunctionDeps(names []string) (funcs []string) {
+	added := make(map[string]bool)
+	for _, name := range names {
+		if !added[name] {
+			added[name] = true
+			funcs = append(funcs, name)
+		}
+	}
+	for i := 0; i < len(funcs); i++ {
+		for _, dep := range functionDeps[funcs[i]] {
+			if !added[dep] {
+				added[dep] = true
+				funcs = append(funcs, dep)
+			}
+		}
+	}
+	return
+}
+
+// InputHash returns a hash of everything the generated code of the table
+// depends on: the go2sql version, the templates, the functions and the
+// columns of the table and its related tables, including the ones promoted
+// from embedded and inlined structs.
+func (t *Table) InputHash(templates *template.Template, funcs []string) string {
+	h := sha256.New()
+	fmt.Fprintln(h, Version, t.Package, strings.Join(funcs, ","))
+
+	tmpls := templates.Templates()
+	sort.Slice(tmpls, func(i, j int) bool { return tmpls[i].Name() < tmpls[j].Name() })
+	for _, tmpl := range tmpls {
+		if tmpl.Tree != nil {
+			fmt.Fprintln(h, tmpl.Name(), tmpl.Tree.Root.String())
+		}
+	}
+
+	tables := []*Table{t}
+	for _, c := range t.Columns {
+		if c.TypeTable != nil {
+			tables = append(tables, c.TypeTable)
+		}
+	}
+	for _, table := range tables {
+		fmt.Fprintln(h, table.Name, table.SQLName)
+		for _, c := range table.Columns {
+			fmt.Fprintln(h, c.Name, c.Field, c.SQLName, c.Type, types.TypeString(c.field.Type(), nil),
+				c.IsPrimaryKey, c.Relationship, c.TableType, c.IsPointer, c.IsJSON, c.IsArray,
+				c.ForeignKey, c.References, c.JoinTable, c.JoinFK, c.JoinRef, c.flags)
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// Generate renders the header and the specified function templates and
+// returns the gofmt-ed source.
+func (t *Table) Generate(templates *template.Template, funcs []string) ([]byte, error) {
+	t.Hash = t.InputHash(templates, funcs)
+	t.w.Reset()
+	for _, name := range append([]string{"header"}, funcs...) {
+		if templates.Lookup(name) == nil {
+			return nil, fmt.Errorf("unknown function template %s", name)
+		}
+		if err := templates.ExecuteTemplate(&t.w, name, t); err != nil {
+			return nil, err
+		}
+	}
+
+	src, err := format.Source(t.w.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if src, err = removeUnusedImports(src); err != nil {
+		return nil, err
+	}
+	return format.Source(src)
+}
 
 func (t *Table) NoTableColumns() (cs []*Column) {
 	for _, c := range t.Columns {
@@ -215,13 +507,36 @@ func (t *Table) NoTableColumns() (cs []*Column) {
 	return
 }
 
+// InsertColumns returns the columns written by an insert, leaving out the id
+// column which is assigned by the database.
+func (t *Table) InsertColumns() (cs []*Column) {
+	for _, c := range t.NoTableColumns() {
+		if c == t.IDColumn {
+			continue
+		}
+		cs = append(cs, c)
+	}
+	return
+}
+
+// ValueColumns returns the columns written by an update.
+func (t *Table) ValueColumns() (cs []*Column) {
+	for _, c := range t.NoTableColumns() {
+		if c.IsPrimaryKey {
+			continue
+		}
+		cs = append(cs, c)
+	}
+	return
+}
+
 func (t *Table) TableColumns(typs ...string) (cs []*Column) {
 	var typ string
 	if len(typs) > 0 {
 		typ = typs[0]
 	}
 	for _, c := range t.Columns {
-		if !c.IsTable {
+		if !c.IsTable || c.Relationship == RelationshipNone {
 			continue
 		}
 		if typ == "has" {
@@ -244,21 +559,234 @@ func (t *Table) ColumnNamesString(cs []*Column, typ string) (s string) {
 		switch typ {
 		case "sql":
 			strs = append(strs, strconv.Quote(c.SQLName))
+		case "sql-name":
+			strs = append(strs, quote(c.SQLName))
+		case "placeholder":
+			strs = append(strs, "?")
+		case "set":
+			strs = append(strs, quote(c.SQLName)+" = ?")
+		case "const":
+			strs = append(strs, t.Name+"Column"+c.Name)
 		case "*go":
-			strs = append(strs, fmt.Sprintf("&%s.%s", t.RefName, c.Name))
+			strs = append(strs, c.ExpScanDest(t.RefName))
 		case "go":
-			strs = append(strs, fmt.Sprintf("%s.%s", t.RefName, c.Name))
+			strs = append(strs, c.ExpArg(t.RefName))
 		}
 	}
-	return strings.Join(strs, ",")
+	return strings.Join(strs, ", ")
+}
+
+// IsScanner reports whether a pointer to the column type implements
+// sql.Scanner.
+func (c *Column) IsScanner() bool {
+	return hasMethod(types.NewPointer(c.field.Type()), "Scan", 1, 1)
+}
+
+// IsValuer reports whether the column type implements driver.Valuer.
+func (c *Column) IsValuer() bool {
+	return hasMethod(c.field.Type(), "Value", 0, 2)
+}
+
+func hasMethod(typ types.Type, name string, params, results int) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, false, nil, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == params && sig.Results().Len() == results
+}
+
+// basicType returns the type the column is converted to for database/sql, e.g.
+// string for template.HTML, or an empty string if it's passed through as is:
+// basic types, time.Time, pointers and the types implementing sql.Scanner or
+// driver.Valuer.
+func (c *Column) basicType() string {
+	named, ok := c.field.Type().(*types.Named)
+	if !ok {
+		return ""
+	}
+	switch utyp := named.Underlying().(type) {
+	case *types.Basic:
+		return utyp.Name()
+	case *types.Slice:
+		if basic, ok := utyp.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return "[]byte"
+		}
+	}
+	return ""
+}
+
+// ExpScanDest returns the pointer to the column of recv to scan into, which
+// is converted to a pointer to the underlying basic type for named types that
+// are not sql.Scanners, e.g. (*string)(&l.HTML) for template.HTML.
+func (c *Column) ExpScanDest(recv string) string {
+	if c.IsJSON {
+		return fmt.Sprintf("go2sql.JSON(&%s.%s)", recv, c.Field)
+	}
+	if c.IsArray {
+		return fmt.Sprintf("go2sql.Array(dialect, &%s.%s)", recv, c.Field)
+	}
+	if basic := c.basicType(); basic != "" && !c.IsScanner() {
+		return fmt.Sprintf("(*%s)(&%s.%s)", basic, recv, c.Field)
+	}
+	return fmt.Sprintf("&%s.%s", recv, c.Field)
+}
+
+// ExpArg returns the column of recv as a query argument, which is converted to
+// the underlying basic type for named types that are not driver.Valuers,
+// e.g. string(l.HTML) for template.HTML.
+func (c *Column) ExpArg(recv string) string {
+	if c.IsJSON {
+		return fmt.Sprintf("go2sql.JSON(&%s.%s)", recv, c.Field)
+	}
+	if c.IsArray {
+		return fmt.Sprintf("go2sql.Array(dialect, &%s.%s)", recv, c.Field)
+	}
+	if basic := c.basicType(); basic != "" && !c.IsValuer() {
+		return fmt.Sprintf("%s(%s.%s)", basic, recv, c.Field)
+	}
+	return fmt.Sprintf("%s.%s", recv, c.Field)
+}
+
+// nullable returns how the column stores NULL: the number of pointers to
+// dereference, or the value field of sql.Null* types.
+func (c *Column) nullable() (pointers int, valueField string, valueType types.Type) {
+	typ := c.field.Type()
+	for {
+		ptr, ok := typ.(*types.Pointer)
+		if !ok {
+			break
+		}
+		pointers++
+		typ = ptr.Elem()
+	}
+	if pointers == 0 {
+		valueField, valueType = nullValueField(typ)
+	}
+	if valueType == nil {
+		valueType = typ
+	}
+	return
+}
+
+// nullValueField returns the value field of the sql.Null* types, e.g. Int64
+// of sql.NullInt64.
+func nullValueField(typ types.Type) (string, types.Type) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "database/sql" {
+		return "", nil
+	}
+	struc, ok := named.Underlying().(*types.Struct)
+	if !ok || struc.NumFields() != 2 || struc.Field(1).Name() != "Valid" {
+		return "", nil
+	}
+	return struc.Field(0).Name(), struc.Field(0).Type()
+}
+
+// IsNullable reports whether the column is a pointer or a sql.Null* type.
+func (c *Column) IsNullable() bool {
+	pointers, valueField, _ := c.nullable()
+	return pointers > 0 || valueField != ""
+}
+
+// ValueType returns the type of the column values, e.g. uint for *uint and
+// int64 for sql.NullInt64.
+func (c *Column) ValueType() string {
+	_, _, typ := c.nullable()
+	return types.TypeString(typ, c.parser.Qualifier)
+}
+
+// ExpIsNull returns the condition checking whether the column of recv is
+// NULL, or an empty string if it's not nullable.
+func (c *Column) ExpIsNull(recv string) string {
+	return c.expNull(recv, "!", "==", " || ")
 }
 
-func (c *Column) ExpIDValue() string {
-	if types.AssignableTo(c.field.Type(), types.Typ[types.Uint]) {
-		return "id"
+// ExpIsNotNull is the negation of ExpIsNull.
+func (c *Column) ExpIsNotNull(recv string) string {
+	return c.expNull(recv, "", "!=", " && ")
+}
+
+func (c *Column) expNull(recv, not, op, join string) string {
+	pointers, valueField, _ := c.nullable()
+	if valueField != "" {
+		return fmt.Sprintf("%s%s.%s.Valid", not, recv, c.Field)
+	}
+	var exps []string
+	for i := 0; i < pointers; i++ {
+		exps = append(exps, fmt.Sprintf("%s%s.%s %s nil", strings.Repeat("*", i), recv, c.Field, op))
 	}
+	if len(exps) > 1 {
+		return "(" + strings.Join(exps, join) + ")"
+	}
+	return strings.Join(exps, "")
+}
 
-	return fmt.Sprintf("%s(id)", types.TypeString(c.field.Type(), c.parser.Qualifier))
+// ExpValue returns the value of the column of recv, which must not be NULL.
+func (c *Column) ExpValue(recv string) string {
+	pointers, valueField, _ := c.nullable()
+	if valueField != "" {
+		return fmt.Sprintf("%s.%s.%s", recv, c.Field, valueField)
+	}
+	return fmt.Sprintf("%s%s.%s", strings.Repeat("*", pointers), recv, c.Field)
+}
+
+// ExpSetValue returns the statements setting the column of recv to value,
+// converted to ValueType.
+func (c *Column) ExpSetValue(recv, value string) string {
+	return c.setValue(recv, fmt.Sprintf("%s(%s)", c.ValueType(), value))
+}
+
+func (c *Column) setValue(recv, value string) string {
+	pointers, valueField, _ := c.nullable()
+	if valueField != "" {
+		return fmt.Sprintf("%s.%s = %s{%s: %s, Valid: true}", recv, c.Field, c.Type, valueField, value)
+	}
+	if pointers == 0 {
+		return fmt.Sprintf("%s.%s = %s", recv, c.Field, value)
+	}
+
+	// pointers to the value are taken from variables named after the column,
+	// e.g. authorIDValue and authorIDValuePtr for **uint.
+	name := varName(c.Name) + "Value"
+	stmts := []string{fmt.Sprintf("%s := %s", name, value)}
+	for i := 1; i < pointers; i++ {
+		ptr := name + "Ptr"
+		stmts = append(stmts, fmt.Sprintf("%s := &%s", ptr, name))
+		name = ptr
+	}
+	stmts = append(stmts, fmt.Sprintf("%s.%s = &%s", recv, c.Field, name))
+	return strings.Join(stmts, "\n")
+}
+
+// ExpSetFrom returns the statements setting the column of recv to the one of
+// src, e.g. a foreign key to the primary key it refers to, setting it to NULL
+// or the zero value if the src column is NULL.
+func (c *Column) ExpSetFrom(recv string, src *Column, srcRecv string) string {
+	if c.Type == src.Type {
+		return fmt.Sprintf("%s.%s = %s.%s", recv, c.Field, srcRecv, src.Field)
+	}
+
+	value := src.ExpValue(srcRecv)
+	if c.ValueType() != src.ValueType() {
+		value = fmt.Sprintf("%s(%s)", c.ValueType(), value)
+	}
+	set := c.setValue(recv, value)
+	if !src.IsNullable() {
+		return set
+	}
+
+	var zero string
+	if c.IsNullable() {
+		zero = fmt.Sprintf("%s.%s = %s{}", recv, c.Field, c.Type)
+		if pointers, _, _ := c.nullable(); pointers > 0 {
+			zero = fmt.Sprintf("%s.%s = nil", recv, c.Field)
+		}
+	} else {
+		zero = fmt.Sprintf("%s.%s = *new(%s)", recv, c.Field, c.Type)
+	}
+	return fmt.Sprintf("if %s {\n%s\n} else {\n%s\n}", src.ExpIsNull(srcRecv), zero, set)
 }
 
 // func (c *Column) TableVarName() string {
@@ -272,7 +800,7 @@ func (c *Column) ExpIDValue() string {
 func (t *Table) ExpSQLWhere() string {
 	var exps []string
 	for _, pk := range t.PrimaryKeys {
-		exps = append(exps, pk.SQLName+"= ?")
+		exps = append(exps, quote(pk.SQLName)+" = ?")
 	}
 	if len(exps) == 1 {
 		return exps[0]
@@ -281,46 +809,262 @@ func (t *Table) ExpSQLWhere() string {
 	return fmt.Sprintf("(%s)", strings.Join(exps, " and "))
 }
 
+// JoinTableName returns the many-to-many join table set by join-table:, or
+// <host table>_<guest table>_xref.
+func (c *Column) JoinTableName() string {
+	if c.JoinTable != "" {
+		return c.JoinTable
+	}
+	return c.Table.SQLName + "_" + c.TypeTable.SQLName + "_xref"
+}
+
+// joinTableColumns returns the join table columns referencing the primary
+// keys of the host and the guest table, set by join-fk: and join-ref: or
+// named <struct>_<primary key>.
+func (c *Column) joinTableColumns() (host, guest []string) {
+	if c.JoinFK != "" {
+		host = []string{c.JoinFK}
+	} else {
+		for _, pk := range c.Table.PrimaryKeys {
+			host = append(host, toSnake(c.Table.Name)+"_"+pk.SQLName)
+		}
+	}
+	if c.JoinRef != "" {
+		guest = []string{c.JoinRef}
+	} else {
+		for _, pk := range c.TypeTable.PrimaryKeys {
+			guest = append(guest, toSnake(c.TypeTable.Name)+"_"+pk.SQLName)
+		}
+	}
+	return
+}
+
 func (c *Column) ExpMany2ManySQLColumns() string {
+	host, guest := c.joinTableColumns()
+	return strings.Join(quoteAll(append(host, guest...)), ", ")
+}
+
+func (c *Column) ExpMany2ManySQLValues() string {
+	return strings.Join(placeholders(len(c.Table.PrimaryKeys)+len(c.TypeTable.PrimaryKeys)), ", ")
+}
+
+func (c *Column) ExpMany2ManyFields(host, guest string) string {
 	var exps []string
 	for _, pk := range c.Table.PrimaryKeys {
-		exps = append(exps, c.Table.SQLName+"_"+pk.SQLName)
+		exps = append(exps, fmt.Sprintf("%s.%s", host, pk.Field))
 	}
 	for _, pk := range c.TypeTable.PrimaryKeys {
-		exps = append(exps, c.TypeTable.SQLName+"_"+pk.SQLName)
+		exps = append(exps, fmt.Sprintf("%s.%s", guest, pk.Field))
 	}
 
 	return strings.Join(exps, ", ")
 }
 
-func (c *Column) ExpMany2ManySQLValues() string {
-	var exps []string
-	for range c.Table.PrimaryKeys {
-		exps = append(exps, "?")
+// ExpMany2ManyHostSQL returns the join table columns referencing the host
+// table, for use in sql IN conditions.
+func (c *Column) ExpMany2ManyHostSQL() string {
+	host, _ := c.joinTableColumns()
+	return sqlTuple(quoteAll(host))
+}
+
+// JoinKey pairs up a column of the host table with the column of the related
+// table it matches.
+type JoinKey struct {
+	Host  *Column
+	Guest *Column
+}
+
+// JoinKeys returns the columns relating the host and the related table. For
+// belongs-to the host columns are the foreign keys, for has-one and has-many
+// the guest columns are. The referenced columns are the primary keys, unless
+// set by references:.
+func (c *Column) JoinKeys() []JoinKey {
+	return c.joinKeys
+}
+
+func (c *Column) setJoinKeys(host, guest []*Column) {
+	c.joinKeys = nil
+	for i := range host {
+		c.joinKeys = append(c.joinKeys, JoinKey{Host: host[i], Guest: guest[i]})
+	}
+}
+
+// relationKeys returns the columns of ref referenced by the relationship and
+// the columns of fk referring to them, together with the names of the
+// latter, which are set by foreign-key: or default to prefix followed by the
+// referenced names, e.g. LanguageID. ok reports whether all of them are
+// found.
+func (c *Column) relationKeys(ref, fk *Table, prefix string) (refs, fks []*Column, names []string, ok bool) {
+	refNames := []string{c.References}
+	if c.References == "" {
+		refNames = nil
+		for _, pk := range ref.PrimaryKeys {
+			refNames = append(refNames, pk.Name)
+		}
+	}
+	if c.ForeignKey != "" {
+		names = []string{c.ForeignKey}
+	} else {
+		for _, name := range refNames {
+			names = append(names, prefix+name)
+		}
 	}
-	for range c.TypeTable.PrimaryKeys {
-		exps = append(exps, "?")
+	if len(refNames) == 0 || len(refNames) != len(names) {
+		return
 	}
 
-	return strings.Join(exps, ", ")
+	for _, name := range refNames {
+		col := ref.GetColumn(name)
+		if col == nil || col.IsTable {
+			return
+		}
+		refs = append(refs, col)
+	}
+
+	for _, name := range names {
+		col := fk.GetColumn(name)
+		if col == nil || col.IsTable {
+			return
+		}
+		fks = append(fks, col)
+	}
+	ok = true
+	return
+}
+
+// ExpGuestKeySQL returns the related table columns matched by the join keys,
+// for use in sql IN conditions.
+func (c *Column) ExpGuestKeySQL() string {
+	var names []string
+	if c.Relationship == RelationshipManyToMany {
+		names = columnSQLNames(c.TypeTable.PrimaryKeys)
+	} else {
+		for _, k := range c.JoinKeys() {
+			names = append(names, quote(k.Guest.SQLName))
+		}
+	}
+	return sqlTuple(names)
 }
 
-func (c *Column) ExpMany2ManyFields() string {
+// ExpHostKeyArg returns the argument matching ExpGuestKeySQL for a host row,
+// a slice of the values if there are several join keys, for the IN
+// conditions expanded by go2sql.SQL.Expand.
+func (c *Column) ExpHostKeyArg(host string) string {
 	var exps []string
-	for _, pk := range c.Table.PrimaryKeys {
-		exps = append(exps, fmt.Sprintf("%s.%s%s", c.TypeTable.VarName, c.Table.Name, pk.SQLName))
+	for _, k := range c.JoinKeys() {
+		if k.Host.IsNullable() {
+			exps = append(exps, k.Host.ExpValue(host))
+		} else {
+			exps = append(exps, k.Host.ExpArg(host))
+		}
 	}
-	for _, pk := range c.TypeTable.PrimaryKeys {
-		exps = append(exps, fmt.Sprintf("%s.%s", c.TypeTable.VarName, pk.Name))
+	return argTuple(exps)
+}
+
+// ExpHostKeysNotNull returns the condition that none of the join keys of a
+// host row is null, or an empty string if they can't be.
+func (c *Column) ExpHostKeysNotNull(host string) string {
+	var exps []string
+	for _, k := range c.JoinKeys() {
+		if exp := k.Host.ExpIsNotNull(host); exp != "" {
+			exps = append(exps, exp)
+		}
 	}
+	return strings.Join(exps, " && ")
+}
 
-	return strings.Join(exps, ", ")
+// ExpJoinKeysMatch returns the condition matching a host row with a related
+// row.
+func (c *Column) ExpJoinKeysMatch(host, guest string) string {
+	var exps []string
+	for _, k := range c.JoinKeys() {
+		if k.Host.Type == k.Guest.Type && !k.Host.IsNullable() {
+			exps = append(exps, fmt.Sprintf("%s.%s == %s.%s", host, k.Host.Field, guest, k.Guest.Field))
+			continue
+		}
+		for _, notNull := range []string{k.Host.ExpIsNotNull(host), k.Guest.ExpIsNotNull(guest)} {
+			if notNull != "" {
+				exps = append(exps, notNull)
+			}
+		}
+		guestValue := k.Guest.ExpValue(guest)
+		if k.Host.ValueType() != k.Guest.ValueType() {
+			guestValue = fmt.Sprintf("%s(%s)", k.Host.ValueType(), guestValue)
+		}
+		exps = append(exps, fmt.Sprintf("%s == %s", k.Host.ExpValue(host), guestValue))
+	}
+	return strings.Join(exps, " && ")
+}
+
+// IsTablePointer reports whether the related rows are referred to by pointers
+// in the host struct.
+func (c *Column) IsTablePointer() bool {
+	typ := c.field.Type().Underlying()
+	switch utyp := typ.(type) {
+	case *types.Slice:
+		typ = utyp.Elem()
+	case *types.Array:
+		typ = utyp.Elem()
+	}
+	_, ok := typ.(*types.Pointer)
+	return ok
 }
 
-var tmpl = template.Must(template.New("tmpl.go").Funcs(template.FuncMap{
-	"const_relationship_none":         func() Relationship { return RelationshipNone },
-	"const_relationship_belongs_to":   func() Relationship { return RelationshipBelongsTo },
-	"const_relationship_has_one":      func() Relationship { return RelationshipHasOne },
-	"const_relationship_has_many":     func() Relationship { return RelationshipHasMany },
-	"const_relationship_many_to_many": func() Relationship { return RelationshipManyToMany },
-}).Parse(rawTmpl))
+// ExpTableRef returns a pointer expression for a related row exp.
+func (c *Column) ExpTableRef(exp string) string {
+	if c.IsTablePointer() {
+		return exp
+	}
+	return "&" + exp
+}
+
+// ExpTableValue returns the expression to assign a related row pointer exp to
+// the host struct.
+func (c *Column) ExpTableValue(exp string) string {
+	if c.IsTablePointer() {
+		return exp
+	}
+	return "*" + exp
+}
+
+func columnSQLNames(cs []*Column) (names []string) {
+	for _, c := range cs {
+		names = append(names, quote(c.SQLName))
+	}
+	return
+}
+
+// quote quotes an identifier of the generated sql, which go2sql.Rebind
+// translates to the quotes of the dialect.
+func quote(name string) string {
+	return "`" + name + "`"
+}
+
+func quoteAll(names []string) (quoted []string) {
+	for _, name := range names {
+		quoted = append(quoted, quote(name))
+	}
+	return
+}
+
+func placeholders(n int) (ps []string) {
+	for i := 0; i < n; i++ {
+		ps = append(ps, "?")
+	}
+	return
+}
+
+func sqlTuple(exps []string) string {
+	if len(exps) == 1 {
+		return exps[0]
+	}
+	return "(" + strings.Join(exps, ", ") + ")"
+}
+
+// argTuple is sqlTuple for the arguments expanded by go2sql.SQL.Expand.
+func argTuple(exps []string) string {
+	if len(exps) == 1 {
+		return exps[0]
+	}
+	return "[]interface{}{" + strings.Join(exps, ", ") + "}"
+}
@@ -0,0 +1,4 @@
+// Package log is named after a package imported by the generated code.
+package log
+
+type Level string
@@ -0,0 +1,65 @@
+package diagnostic
+
+import (
+	"image"
+
+	"github.com/bom-d-van/go2sql/testdata/diagnostic/log"
+)
+
+type Language struct {
+	ID uint `go2sql:",id,primary-key"`
+
+	Author   *Person
+	Keywords []*Keyword
+	Origin   image.Point
+	Meta     map[string]string
+	Tags     [][]string `go2sql:",primary_key"`
+	Editor   *Person    `go2sql:",foreign-key:EditorRef"`
+	Readers  *Person    `go2sql:",many-to-many"`
+	Owner    *Person    `go2sql:",belongs-to"`
+}
+
+type Person struct {
+	ID   uint `go2sql:",id,primary-key"`
+	Name string
+}
+
+type Keyword struct {
+	ID   uint `go2sql:",id,primary-key"`
+	Name string
+}
+
+type Note struct {
+	Text string
+}
+
+type Size struct {
+	X     int
+	Width int
+}
+
+// Draft embeds X from both image.Point and Size.
+type Draft struct {
+	ID uint `go2sql:",id,primary-key"`
+	image.Point
+	Size
+	*Note
+}
+
+// Search has column variables colliding with the name of its query builder,
+// its column registries and the name constant of its Name column.
+type Search struct {
+	ID         uint `go2sql:",id,primary-key"`
+	Query      string
+	Columns    string
+	AllColumns string
+	Name       string
+	ColumnName string
+}
+
+// Entry has a column of a package named like the log package imported by the
+// generated code.
+type Entry struct {
+	ID    uint `go2sql:",id,primary-key"`
+	Level log.Level
+}
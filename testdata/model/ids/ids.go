@@ -0,0 +1,5 @@
+// Package ids declares the key types of the models of another package.
+package ids
+
+// Code is a natural key, e.g. of Region.
+type Code string
@@ -0,0 +1,251 @@
+package model
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/bom-d-van/go2sql/testdata/model/ids"
+)
+
+const (
+	LanguageTableName = "languages"
+)
+
+type MyString string
+
+type Language struct {
+	ID         uint `go2sql:",id,primary-key"`
+	Name       string
+	WordsCount uint `go2sql:"words_stat"`
+
+	Ignored string `go2sql:"-"`
+
+	AuthorID uint
+	Author   *Person
+
+	Tag *Keyword
+
+	Embed struct {
+		Name string
+	}
+
+	MyString MyString
+
+	Keywords []*Keyword
+
+	HTML template.HTML
+
+	Teachers  []*Teacher
+	TeacherID uint
+}
+
+type Keyword struct {
+	ID   uint `go2sql:",id,primary-key"`
+	Name string
+	Type string
+
+	LanguageID uint
+}
+
+type Person struct {
+	ID    uint `go2sql:",id,primary-key"`
+	Name  string
+	Email string
+}
+
+type Teacher struct {
+	ID   uint `go2sql:",id,primary-key"`
+	Name string
+	Age  uint
+
+	LanguageID uint
+}
+
+// LanguageReport is read-only.
+//
+//go2sql:funcs find, find_many
+type LanguageReport struct {
+	ID    uint `go2sql:",id,primary-key"`
+	Name  string
+	Total uint
+}
+
+// Owner and Pet are related by Code instead of ID, and License refers to
+// Owner by HolderID instead of OwnerID.
+type Owner struct {
+	ID   uint `go2sql:",id,primary-key"`
+	Code string
+	Name string
+
+	Pets    []*Pet   `go2sql:",foreign-key:OwnerCode,references:Code"`
+	License *License `go2sql:",foreign-key:HolderID"`
+}
+
+type Pet struct {
+	ID        uint `go2sql:",id,primary-key"`
+	Name      string
+	OwnerCode string
+	Owner     *Owner `go2sql:",foreign-key:OwnerCode,references:Code"`
+}
+
+type License struct {
+	ID       uint `go2sql:",id,primary-key"`
+	Number   string
+	HolderID uint
+}
+
+// Library and Book describe a legacy schema with declared relationships.
+type Library struct {
+	ID   uint `go2sql:",id,primary-key"`
+	Name string
+
+	Books    []*Book `go2sql:",many-to-many,join-table:library_books,join-fk:lib_id,join-ref:book_ref"`
+	Featured *Book   `go2sql:",has-one,foreign-key:FeaturedIn"`
+}
+
+type Book struct {
+	ID         uint `go2sql:",id,primary-key"`
+	Title      string
+	FeaturedIn uint
+}
+
+type Info struct {
+	CreatedAt   time.Time
+	Description string
+}
+
+type Origin struct {
+	Country string
+	Info    Info `go2sql:",inline"`
+}
+
+// Article inlines Info into info_created_at and info_description, and Origin
+// into origin_country, origin_info_created_at and origin_info_description.
+type Article struct {
+	ID     uint   `go2sql:",id,primary-key"`
+	Info   Info   `go2sql:",inline"`
+	Origin Origin `go2sql:",prefix:origin_"`
+}
+
+type Timestamps struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Audit struct {
+	Timestamps
+	CreatedBy string
+}
+
+// Post embeds Audit, whose UpdatedAt is shadowed by the one of Post.
+type Post struct {
+	ID    uint `go2sql:",id,primary-key"`
+	Title string
+	Audit
+	UpdatedAt time.Time `go2sql:"modified_at"`
+}
+
+// Comment has nullable columns, and belongs to an optional Person through
+// AuthorID.
+type Comment struct {
+	ID          uint `go2sql:",id,primary-key"`
+	Body        sql.NullString
+	Likes       sql.NullInt64
+	PublishedAt sql.NullTime
+	Score       *float64
+	AuthorID    **uint
+	Author      *Person
+	Replies     []*Reply
+	Meta        map[string]string `go2sql:",json"`
+	Labels      []string          `go2sql:",json"`
+	Settings    *Settings         `go2sql:",json"`
+}
+
+// Settings is stored as JSON by Comment, rather than in a table.
+type Settings struct {
+	Notify bool
+	Theme  string
+}
+
+type Reply struct {
+	ID        uint `go2sql:",id,primary-key"`
+	Body      string
+	Mood      Mood
+	CommentID sql.NullInt64
+	Tags      []string
+	Votes     []int64
+}
+
+// Mood is stored as its name.
+type Mood int
+
+const (
+	MoodNeutral Mood = iota
+	MoodHappy
+)
+
+var moodNames = []string{"neutral", "happy"}
+
+func (m *Mood) Scan(src interface{}) error {
+	name, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("can't scan %T into Mood", src)
+	}
+	for i, n := range moodNames {
+		if n == string(name) {
+			*m = Mood(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown mood %s", name)
+}
+
+func (m Mood) Value() (driver.Value, error) {
+	return moodNames[m], nil
+}
+
+// Enrollment is keyed by StudentID and CourseID, which are not generated by
+// the database, and Attendance refers to it by both of them.
+type Enrollment struct {
+	StudentID   uint `go2sql:",primary-key"`
+	CourseID    uint `go2sql:",primary-key"`
+	Grade       string
+	Student     *Student
+	Course      *Course
+	Attendances []*Attendance
+}
+
+type Student struct {
+	ID          uint `go2sql:",id,primary-key"`
+	Name        string
+	Enrollments []*Enrollment
+}
+
+type Course struct {
+	ID    uint `go2sql:",id,primary-key"`
+	Title string
+}
+
+type Attendance struct {
+	ID                  uint `go2sql:",id,primary-key"`
+	Day                 time.Time
+	EnrollmentStudentID uint
+	EnrollmentCourseID  uint
+	Enrollment          *Enrollment
+}
+
+// Region and Depot are keyed by ids.Code of another package, which their
+// generated code imports for the xrefs of Depots.
+type Region struct {
+	Code   ids.Code `go2sql:",primary-key"`
+	Name   string
+	Depots []*Depot `go2sql:",many-to-many"`
+}
+
+type Depot struct {
+	Code ids.Code `go2sql:",primary-key"`
+	Name string
+}
@@ -0,0 +1,8 @@
+//go:build go2sql_tagged
+
+package model
+
+type Tagged struct {
+	ID   uint `go2sql:",id,primary-key"`
+	Name string
+}
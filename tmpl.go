@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strconv"
+	"text/template"
+)
+
+// Version is the go2sql version stamped on the generated files. Bump it
+// whenever the templates change the generated code.
+const Version = "0.2.0"
+
+//go:embed templates/*.tmpl
+var tmplFS embed.FS
+
+var tmpl = template.Must(template.New("tmpl.go").Funcs(template.FuncMap{
+	"version": func() string { return Version },
+
+	"const_relationship_none":         func() Relationship { return RelationshipNone },
+	"const_relationship_belongs_to":   func() Relationship { return RelationshipBelongsTo },
+	"const_relationship_has_one":      func() Relationship { return RelationshipHasOne },
+	"const_relationship_has_many":     func() Relationship { return RelationshipHasMany },
+	"const_relationship_many_to_many": func() Relationship { return RelationshipManyToMany },
+}).ParseFS(tmplFS, "templates/*.tmpl"))
+
+// loadTemplates returns the built-in templates with the ones defined in the
+// *.tmpl files of dir, which override the built-in templates of the same name.
+func loadTemplates(dir string) (*template.Template, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no *.tmpl files found in %s", dir)
+	}
+
+	t, err := tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return t.ParseFiles(files...)
+}
+
+// removeUnusedImports drops the imports of the header that are not referred
+// to by the generated functions.
+func removeUnusedImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	var decls []ast.Decl
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+
+		var specs []ast.Spec
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			ipath, _ := strconv.Unquote(imp.Path.Value)
+			name := path.Base(ipath)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if name == "_" || used[name] {
+				specs = append(specs, spec)
+			}
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		gen.Specs = specs
+		decls = append(decls, gen)
+	}
+	f.Decls = decls
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestTemplates(t *testing.T) {
+	pkg, err := loadPackage("./testdata/model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(pkg.Name)
+	if err := p.Parse(pkg.Info); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ParseDirectives(pkg.Files); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, typ := range []string{"Language", "Keyword", "Person", "Teacher", "LanguageReport", "Owner", "Pet", "License", "Library", "Book", "Article", "Post", "Comment", "Reply", "Enrollment", "Student", "Course", "Attendance", "Region", "Depot"} {
+		table := p.Tables[typ]
+		table.Package = pkg.Name
+		src, err := table.Generate(tmpl, table.Functions(DefaultFunctions))
+		if err != nil {
+			printWithLineNo(table.w.String())
+			t.Fatalf("%s: %s", typ, err)
+		}
+
+		golden := filepath.Join("testdata", "model", go2sqlFileName(typ)+".golden")
+		if *updateGolden {
+			if err := ioutil.WriteFile(golden, src, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(src, want) {
+			t.Errorf("%s: generated code differs from %s; run go test -update to refresh it", typ, golden)
+		}
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	templates, err := loadTemplates("testdata/templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if templates.Lookup("zero_keys") == nil {
+		t.Error("template zero_keys is not added")
+	}
+
+	pkg, err := loadPackage("./testdata/model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(pkg.Name)
+	if err := p.Parse(pkg.Info); err != nil {
+		t.Fatal(err)
+	}
+	table := p.Tables["Person"]
+	table.Package = pkg.Name
+	src, err := table.Generate(templates, []string{"is_new_row", "find"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(src, []byte("// IsNewRow is overridden by testdata/templates.")) {
+		t.Errorf("is_new_row is not overridden:\n%s", src)
+	}
+	if !bytes.Contains(src, []byte("func FindPerson(")) {
+		t.Errorf("find is not rendered by the built-in template:\n%s", src)
+	}
+
+	if _, err := loadTemplates("testdata/model"); err == nil {
+		t.Error("expect error for directory without templates")
+	}
+}
+
+func TestTableFunctions(t *testing.T) {
+	cases := []struct {
+		option, defaults, want []string
+	}{
+		{nil, DefaultFunctions, DefaultFunctions},
+		{nil, []string{"find"}, []string{"find", "fetch"}},
+		{[]string{"update_many"}, DefaultFunctions, []string{"update_many", "update", "insert", "is_new_row"}},
+		{[]string{"delete", "is_new_row"}, DefaultFunctions, []string{"delete", "is_new_row"}},
+		{[]string{"query"}, DefaultFunctions, []string{"query", "find", "find_many", "fetch"}},
+	}
+	for _, c := range cases {
+		table := &Table{Option: Option{Functions: c.option}}
+		if got := table.Functions(c.defaults); strings.Join(got, ",") != strings.Join(c.want, ",") {
+			t.Errorf("Functions(%v) with %v = %v; want %v", c.defaults, c.option, got, c.want)
+		}
+	}
+}
+
+func TestParserFunctions(t *testing.T) {
+	pkg, err := loadPackage("./testdata/model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(pkg.Name)
+	if err := p.Parse(pkg.Info); err != nil {
+		t.Fatal(err)
+	}
+	p.Tables["Keyword"].Option.Functions = []string{"find"}
+	p.Tables["Person"].Option.Functions = []string{"find"}
+
+	// Language saves its Author by Insert, its Tag by Update and its
+	// Keywords by Keywords.Update; Teacher isn't generated.
+	funcs := p.Functions([]string{"Language", "Keyword", "Person"}, []string{"insert"})
+	for typ, want := range map[string][]string{
+		"Language": {"insert", "is_new_row"},
+		"Keyword":  {"find", "fetch", "is_empty_row", "update", "insert", "is_new_row", "update_many"},
+		"Person":   {"find", "fetch", "is_empty_row", "insert", "is_new_row"},
+	} {
+		if got := funcs[typ]; strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: Functions = %v; want %v", typ, got, want)
+		}
+	}
+	if _, ok := funcs["Teacher"]; ok {
+		t.Error("Functions returns Teacher, which isn't generated")
+	}
+}
+
+func TestInputHash(t *testing.T) {
+	pkg, err := loadPackage("./testdata/model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(pkg.Name)
+	if err := p.Parse(pkg.Info); err != nil {
+		t.Fatal(err)
+	}
+	language := p.Tables["Language"]
+	language.Package = pkg.Name
+
+	hash := language.InputHash(tmpl, DefaultFunctions)
+	if got := generatedHash(filepath.Join("testdata", "model", "language_go2sql.go.golden")); got != hash {
+		t.Errorf("golden hash = %s; want %s", got, hash)
+	}
+	if got := language.InputHash(tmpl, []string{"find"}); got == hash {
+		t.Error("hash doesn't change with the functions")
+	}
+	templates, err := loadTemplates("testdata/templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := language.InputHash(templates, DefaultFunctions); got == hash {
+		t.Error("hash doesn't change with the templates")
+	}
+
+	// Language belongs to Person, so the hash changes with it.
+	person := p.Tables["Person"]
+	columns := person.Columns
+	person.Columns = append(person.Columns[:len(columns):len(columns)], &Column{Name: "Extra", Field: "Extra", SQLName: "extra", field: columns[0].field})
+	if got := language.InputHash(tmpl, DefaultFunctions); got == hash {
+		t.Error("hash doesn't change with the related struct")
+	}
+	person.Columns = columns
+
+	// The struct type of Post names Audit only, but the hash changes with the
+	// columns promoted from it.
+	post := p.Tables["Post"]
+	post.Package = pkg.Name
+	hash = post.InputHash(tmpl, DefaultFunctions)
+	for _, c := range post.Columns {
+		if strings.HasPrefix(c.Field, "Audit.") {
+			c.Type = "int64"
+		}
+	}
+	if got := post.InputHash(tmpl, DefaultFunctions); got == hash {
+		t.Error("hash doesn't change with the embedded columns")
+	}
+}
+
+func TestWriteGenerated(t *testing.T) {
+	pkg, err := loadPackage("./testdata/model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(pkg.Name)
+	if err := p.Parse(pkg.Info); err != nil {
+		t.Fatal(err)
+	}
+	language := p.Tables["Language"]
+	language.Package = pkg.Name
+
+	dir, err := ioutil.TempDir("", "go2sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, go2sqlFileName("Language"))
+	golden, err := ioutil.ReadFile(filepath.Join("testdata", "model", go2sqlFileName("Language")+".golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(funcs []string, force, want bool) {
+		t.Helper()
+		written, err := writeGenerated(language, tmpl, funcs, filename, force)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != want {
+			t.Errorf("writeGenerated(%v, force %t) = %t; want %t", funcs, force, written, want)
+		}
+	}
+	write(DefaultFunctions, false, true)
+	if src, _ := ioutil.ReadFile(filename); !bytes.Equal(src, golden) {
+		t.Error("written file differs from the golden file")
+	}
+
+	// The file isn't rendered again while its hash is up to date, so the
+	// edits keeping the hash are left alone.
+	edited := append(golden[:len(golden):len(golden)], "// edited\n"...)
+	if err := ioutil.WriteFile(filename, edited, 0644); err != nil {
+		t.Fatal(err)
+	}
+	write(DefaultFunctions, false, false)
+	if src, _ := ioutil.ReadFile(filename); !bytes.Equal(src, edited) {
+		t.Error("up-to-date file is rewritten")
+	}
+
+	write(DefaultFunctions, true, true)
+	if src, _ := ioutil.ReadFile(filename); !bytes.Equal(src, golden) {
+		t.Error("forced file differs from the golden file")
+	}
+	write([]string{"find"}, false, true)
+	write([]string{"find"}, false, false)
+}
@@ -1,20 +1,32 @@
 package main
 
 import (
+	"fmt"
 	"go/ast"
+	"go/constant"
+	"go/token"
 	"go/types"
-	"log"
+	"path"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"bitbucket.org/pkg/inflect"
 )
 
+// DirectivePrefix starts the comment directives on struct declarations, e.g.
+//
+//	//go2sql:funcs find,find_many
+const DirectivePrefix = "//go2sql:"
+
 type Parser struct {
 	Package string
+	Fset    *token.FileSet // positions the diagnostics if set
 	Consts  map[string]string
 	Tables  map[string]*Table
+
+	Diagnostics []Diagnostic
 }
 
 func NewParser(pkg string) *Parser {
@@ -32,10 +44,12 @@ func (p *Parser) Parse(info types.Info) (err error) {
 		}
 		switch ident.Obj.Kind {
 		case ast.Con:
-			p.Consts[obj.Name()], err = strconv.Unquote(info.Types[ident.Obj.Decl.(*ast.ValueSpec).Values[0]].Value.String())
-			if err != nil {
-				return
+			// only the string constants might be table names
+			c, ok := obj.(*types.Const)
+			if !ok || c.Val().Kind() != constant.String {
+				continue
 			}
+			p.Consts[obj.Name()] = constant.StringVal(c.Val())
 		case ast.Typ:
 			struc, ok := obj.Type().Underlying().(*types.Struct)
 			if !ok {
@@ -45,48 +59,24 @@ func (p *Parser) Parse(info types.Info) (err error) {
 			var table Table
 			// table.struc = node
 			table.Name = ident.Name
+			table.typ = obj.Type()
+			table.pos = obj.Pos()
 			table.RefName = strings.ToLower(ident.Name[:1])
 			table.VarName = strings.ToLower(ident.Name[:1]) + ident.Name[1:]
-			table.ColName = inflect.Pluralize(table.Name)
-			table.ColRefName = inflect.Pluralize(table.RefName)
-			table.ColVarName = inflect.Pluralize(table.VarName)
-			table.SQLName = inflect.Pluralize(toSnake(table.Name)) + TableNameSuffix
-
-			for i := 0; i < struc.NumFields(); i++ {
-				field := struc.Field(i)
-				flags := strings.Split(reflect.StructTag(struc.Tag(i)).Get("go2sql"), ",")
-
-				var column Column
-				column.Name = field.Name()
-				column.field = field
-				column.flags = flags
-				column.Table = &table
-				column.parser = p
-
-				if len(flags) > 0 && flags[0] != "" {
-					if flags[0] == FlagIgnore {
-						continue
-					}
-					column.SQLName = flags[0]
-				} else {
-					column.SQLName = toSnake(column.Name)
-				}
-
-				_, column.IsPointer = field.Type().(*types.Pointer)
-				if contains(flags, FlagID) {
-					table.IDColumn = &column
-				}
-				if contains(flags, FlagPK) {
-					column.IsPrimaryKey = true
-					table.PrimaryKeys = append(table.PrimaryKeys, &column)
-				}
+			table.ColName = pluralize(table.Name)
+			table.ColRefName = pluralize(table.RefName)
+			if table.ColRefName == table.RefName {
+				// e.g. s of Student
+				table.ColRefName += "s"
+			}
+			table.ColVarName = pluralize(table.VarName)
+			table.SQLName = inflect.Pluralize(toSnake(table.Name))
 
-				column.Type = types.TypeString(field.Type(), p.Qualifier)
-				column.IsTable, column.TableType, column.Relationship = p.IsTable(field.Type())
-				if column.IsTable && contains(flags, FlagInline) {
-					column.IsTable = false
-				}
-				table.Columns = append(table.Columns, &column)
+			p.parseColumns(&table, struc, "", "", "", 0)
+			p.promoteColumns(&table)
+			p.checkColumnNames(&table)
+			if len(table.PrimaryKeys) == 0 {
+				p.warnf(table.Name, table.pos, "%s has no primary key, mark one with go2sql:\",primary-key\"", table.Name)
 			}
 			p.Tables[table.Name] = &table
 		}
@@ -104,55 +94,538 @@ func (p *Parser) Parse(info types.Info) (err error) {
 			}
 			guest := p.Tables[hostc.TableType]
 			if guest == nil {
-				log.Printf("can't found struct %s\n", hostc.TableType)
+				p.warnf(host.Name, hostc.field.Pos(), "%s.%s: %s is not a struct declared in package %s, the field is ignored, store it as JSON with go2sql:\",json\"", host.Name, hostc.Name, hostc.TableType, p.Package)
+				hostc.Relationship = RelationshipNone
 				continue
 			}
 
-			hostc.TypeTable = guest
-			if hostc.Relationship == RelationshipHasOne {
-				// reanalyze if it's a valid belongs-to
-				belongsTo := true
-				for _, pk := range guest.PrimaryKeys {
-					// TODO: custome primary key naming
-					belongsTo = belongsTo && host.HasColumn(hostc.Name+pk.Name)
-				}
-				if belongsTo {
-					hostc.Relationship = RelationshipBelongsTo
+			p.parseRelationship(host, hostc, guest)
+		}
+	}
+
+	p.parseImports()
+	return
+}
+
+// headerImports are the paths of the packages imported by the header
+// template, by name.
+var headerImports = map[string]string{
+	"sql":     "database/sql",
+	"fmt":     "fmt",
+	"log":     "log",
+	"strings": "strings",
+	"go2sql":  "github.com/bom-d-van/go2sql/go2sql",
+}
+
+// parseImports sets the imports of each table to the packages of the column
+// types of it and its related tables, which the generated code refers to by
+// their names, e.g. in the xrefs of many-to-many relationships. Packages of
+// the same name can't be imported together, so they're reported.
+func (p *Parser) parseImports() {
+	names := make([]string, 0, len(p.Tables))
+	for name := range p.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		table := p.Tables[name]
+		tables := []*Table{table}
+		for _, c := range table.Columns {
+			if c.TypeTable != nil {
+				tables = append(tables, c.TypeTable)
+			}
+		}
+
+		paths := make(map[string]string)
+		for _, t := range tables {
+			for _, c := range t.Columns {
+				if c.IsTable || c.IsJSON || c.IsArray {
 					continue
 				}
-
-				// reanalyze if it's a valid has-one
-				hasOne := true
-				for _, pk := range host.PrimaryKeys {
-					// TODO: custome primary key naming
-					hasOne = hasOne && guest.HasColumn(host.Name+pk.Name)
+				var pkgs []*types.Package
+				collect := func(pkg *types.Package) string {
+					for _, seen := range pkgs {
+						if seen == pkg {
+							return pkg.Name()
+						}
+					}
+					pkgs = append(pkgs, pkg)
+					return pkg.Name()
 				}
-				if !hasOne {
-					hostc.Relationship = RelationshipNone
+				_, _, valueType := c.nullable()
+				types.TypeString(c.field.Type(), collect)
+				types.TypeString(valueType, collect)
+
+				for _, pkg := range pkgs {
+					name := p.Qualifier(pkg)
+					if name == "" {
+						continue
+					}
+					ipath, ok := headerImports[name]
+					if !ok {
+						ipath, ok = paths[name]
+					}
+					if !ok {
+						paths[name] = pkg.Path()
+					} else if ipath != pkg.Path() {
+						p.errorf(table.Name, c.field.Pos(), "%s.%s: the package %s of its type has the same name as %s, which the generated code of %s imports too", t.Name, c.Field, pkg.Path(), ipath, table.Name)
+					}
 				}
-			} else if hostc.Relationship == RelationshipHasMany {
-				hasMany := true
-				for _, pk := range host.PrimaryKeys {
-					// TODO: custome primary key naming
-					hasMany = hasMany && guest.HasColumn(host.Name+pk.Name)
+			}
+		}
+
+		table.Imports = nil
+		for name, ipath := range paths {
+			spec := strconv.Quote(ipath)
+			if name != path.Base(ipath) {
+				spec = name + " " + spec
+			}
+			table.Imports = append(table.Imports, spec)
+		}
+		sort.Strings(table.Imports)
+	}
+}
+
+// parseColumns adds the fields of struc to table as columns. The fields of
+// inlined structs are added with their selector, name and sql name prefixed,
+// e.g. Info.CreatedAt, InfoCreatedAt and info_created_at.
+//
+// The fields of embedded structs are promoted, keeping their names and sql
+// names, with depth counting the embedding levels like Go does.
+func (p *Parser) parseColumns(table *Table, struc *types.Struct, fieldPrefix, namePrefix, sqlPrefix string, depth int) {
+	for i := 0; i < struc.NumFields(); i++ {
+		field := struc.Field(i)
+		if !field.Exported() && field.Pkg() != nil && field.Pkg().Name() != p.Package {
+			continue
+		}
+		flags := strings.Split(reflect.StructTag(struc.Tag(i)).Get("go2sql"), ",")
+
+		var column Column
+		column.Name = namePrefix + field.Name()
+		column.Field = fieldPrefix + field.Name()
+		column.field = field
+		column.flags = flags
+		column.depth = depth
+		column.pos = field.Pos()
+		column.IsJSON = contains(flags, FlagJSON)
+		column.Table = table
+		column.parser = p
+
+		if len(flags) > 0 && flags[0] != "" {
+			if flags[0] == FlagIgnore {
+				continue
+			}
+			column.SQLName = sqlPrefix + flags[0]
+		} else {
+			column.SQLName = sqlPrefix + toSnake(field.Name())
+		}
+		for _, flag := range flags[1:] {
+			if !isKnownFlag(flag) {
+				p.warnf(table.Name, field.Pos(), "%s.%s: unknown go2sql option %q", table.Name, column.Field, flag)
+			}
+		}
+
+		if prefix, ok := flagValue(flags, FlagPrefix); ok || contains(flags, FlagInline) {
+			inline, isStruct := field.Type().Underlying().(*types.Struct)
+			if !isStruct {
+				p.errorf(table.Name, field.Pos(), "%s.%s: can't inline %s, which is not a struct", table.Name, column.Field, types.TypeString(field.Type(), p.Qualifier))
+				continue
+			}
+			if !ok {
+				prefix = toSnake(field.Name()) + "_"
+			}
+			n := len(table.Columns)
+			p.parseColumns(table, inline, column.Field+".", column.Name, sqlPrefix+prefix, depth)
+			for _, c := range table.Columns[n:] {
+				c.pos = field.Pos()
+			}
+			continue
+		}
+
+		if field.Embedded() && !column.IsJSON && !isTimeType(field.Type()) && !isNullType(field.Type()) {
+			if _, ok := field.Type().(*types.Pointer); ok {
+				p.errorf(table.Name, field.Pos(), "%s.%s: embedded pointers are not supported, skip it with go2sql:\"-\"", table.Name, column.Field)
+				continue
+			}
+			if embedded, ok := field.Type().Underlying().(*types.Struct); ok {
+				n := len(table.Columns)
+				p.parseColumns(table, embedded, column.Field+".", namePrefix, sqlPrefix, depth+1)
+				for _, c := range table.Columns[n:] {
+					c.pos = field.Pos()
 				}
-				if !hasMany {
-					hostc.Relationship = RelationshipNone
+				continue
+			}
+		}
+
+		column.ForeignKey, _ = flagValue(flags, FlagForeignKey)
+		column.References, _ = flagValue(flags, FlagReferences)
+		column.JoinTable, _ = flagValue(flags, FlagJoinTable)
+		column.JoinFK, _ = flagValue(flags, FlagJoinFK)
+		column.JoinRef, _ = flagValue(flags, FlagJoinRef)
+		for _, r := range []Relationship{RelationshipBelongsTo, RelationshipHasOne, RelationshipHasMany, RelationshipManyToMany} {
+			if contains(flags, r.String()) {
+				column.declared = r
+			}
+		}
+		if column.declared == RelationshipNone && (column.JoinTable != "" || column.JoinFK != "" || column.JoinRef != "") {
+			column.declared = RelationshipManyToMany
+		}
+
+		_, column.IsPointer = field.Type().(*types.Pointer)
+		if contains(flags, FlagID) {
+			table.IDColumn = &column
+		}
+		if contains(flags, FlagPK) {
+			column.IsPrimaryKey = true
+			table.PrimaryKeys = append(table.PrimaryKeys, &column)
+		}
+
+		column.Type = types.TypeString(field.Type(), p.Qualifier)
+		if column.IsJSON {
+			if !isJSONType(field.Type()) {
+				p.errorf(table.Name, field.Pos(), "%s.%s: %s can't be stored as JSON, skip it with go2sql:\"-\"", table.Name, column.Field, column.Type)
+			}
+			table.Columns = append(table.Columns, &column)
+			continue
+		}
+		if column.IsArray = isArrayType(field.Type()); column.IsArray {
+			table.Columns = append(table.Columns, &column)
+			continue
+		}
+		column.IsTable, column.TableType, column.Relationship = p.IsTable(field.Type())
+		if column.IsTable && (fieldPrefix != "" || depth > 0) {
+			p.errorf(table.Name, field.Pos(), "%s.%s: relationships in inlined structs are not supported, skip it with go2sql:\"-\"", table.Name, column.Field)
+			continue
+		}
+		if !column.IsTable && !isSupportedType(field.Type()) {
+			p.errorf(table.Name, field.Pos(), "%s.%s: unsupported type %s, skip it with go2sql:\"-\"", table.Name, column.Field, column.Type)
+		}
+		table.Columns = append(table.Columns, &column)
+	}
+}
+
+// promoteColumns drops the columns shadowed by the ones of the same name
+// embedded less deeply, like Go field promotion does, and reports the columns
+// of the same name or sql name which can't be told apart.
+func (p *Parser) promoteColumns(table *Table) {
+	dropped := make(map[*Column]bool)
+	for i, c := range table.Columns {
+		shallowest := []*Column{c}
+		seen := false
+		for j, o := range table.Columns {
+			if o.Name != c.Name || o == c {
+				continue
+			}
+			if j < i {
+				seen = true
+				break
+			}
+			if o.depth < shallowest[0].depth {
+				shallowest = []*Column{o}
+			} else if o.depth == shallowest[0].depth {
+				shallowest = append(shallowest, o)
+			}
+		}
+		if seen {
+			continue
+		}
+
+		for _, o := range table.Columns {
+			if o.Name == c.Name && (o != shallowest[0] || len(shallowest) > 1) {
+				dropped[o] = true
+			}
+		}
+		if len(shallowest) > 1 {
+			var fields []string
+			for _, o := range shallowest {
+				fields = append(fields, o.Field)
+			}
+			p.errorf(table.Name, shallowest[0].pos, "%s: ambiguous column %s of %s, skip all but one of them with go2sql:\"-\"", table.Name, c.Name, strings.Join(fields, " and "))
+		}
+	}
+
+	var columns, pks []*Column
+	sqlNames := make(map[string]*Column)
+	for _, c := range table.Columns {
+		if dropped[c] {
+			continue
+		}
+		if o, ok := sqlNames[c.SQLName]; ok && !c.IsTable {
+			p.errorf(table.Name, c.pos, "%s.%s: column %s is also used by %s.%s", table.Name, c.Field, c.SQLName, table.Name, o.Field)
+		} else if !c.IsTable {
+			sqlNames[c.SQLName] = c
+		}
+		columns = append(columns, c)
+		if c.IsPrimaryKey {
+			pks = append(pks, c)
+		}
+	}
+	table.Columns, table.PrimaryKeys = columns, pks
+	if dropped[table.IDColumn] {
+		table.IDColumn = nil
+	}
+}
+
+// checkColumnNames reports the columns whose variables, e.g. LanguageName of
+// Language.Name, collide with the other identifiers generated for the table,
+// including the name constants of the columns, e.g. LanguageColumnName.
+func (p *Parser) checkColumnNames(table *Table) {
+	generated := map[string]bool{
+		table.Name + "Query":            true,
+		table.Name + "QueryBuilder":     true,
+		table.Name + "Columns":          true,
+		table.Name + "AllColumns":       true,
+		table.Name + "AllRelatedTables": true,
+	}
+	for _, c := range table.Columns {
+		generated[table.Name+"Column"+c.Name] = true
+	}
+	for _, c := range table.Columns {
+		if name := table.Name + c.Name; !c.IsTable && generated[name] {
+			p.errorf(table.Name, c.pos, "%s.%s: its column variable collides with the generated %s, rename the field or skip it with go2sql:\"-\"", table.Name, c.Field, name)
+		}
+	}
+}
+
+// parseRelationship sets up the relationship of hostc, a field of host
+// referring to guest. It's the one declared in the tag if any, or otherwise
+// recognized by the foreign keys.
+func (p *Parser) parseRelationship(host *Table, hostc *Column, guest *Table) {
+	hostc.TypeTable = guest
+	isSlice := hostc.Relationship == RelationshipHasMany
+	explicit := hostc.declared != RelationshipNone || hostc.ForeignKey != "" || hostc.References != ""
+	ignore := func(format string, args ...interface{}) {
+		severity := SeverityWarning
+		if explicit {
+			severity = SeverityError
+		}
+		args = append([]interface{}{host.Name, hostc.Name}, args...)
+		p.report(severity, host.Name, hostc.field.Pos(), "%s.%s "+format+", the field is ignored", args...)
+		hostc.Relationship = RelationshipNone
+	}
+
+	switch hostc.declared {
+	case RelationshipBelongsTo, RelationshipHasOne:
+		if isSlice {
+			ignore("is declared %s but its type %s is a slice", hostc.declared, hostc.Type)
+			return
+		}
+	case RelationshipHasMany, RelationshipManyToMany:
+		if !isSlice {
+			ignore("is declared %s but its type %s is not a slice", hostc.declared, hostc.Type)
+			return
+		}
+	}
+	if hostc.declared != RelationshipManyToMany && (hostc.JoinTable != "" || hostc.JoinFK != "" || hostc.JoinRef != "") {
+		ignore("has join table options but is declared %s", hostc.declared)
+		return
+	}
+
+	switch hostc.declared {
+	case RelationshipBelongsTo:
+		guestKeys, hostKeys, fks, ok := hostc.relationKeys(guest, host, hostc.Name)
+		if !ok {
+			ignore("is declared belongs-to but %s has no %s", host.Name, strings.Join(fks, " and "))
+			return
+		}
+		hostc.Relationship = RelationshipBelongsTo
+		hostc.setJoinKeys(hostKeys, guestKeys)
+		return
+	case RelationshipHasOne, RelationshipHasMany:
+		hostKeys, guestKeys, fks, ok := hostc.relationKeys(host, guest, host.Name)
+		if !ok {
+			ignore("is declared %s but %s has no %s", hostc.declared, guest.Name, strings.Join(fks, " and "))
+			return
+		}
+		hostc.Relationship = hostc.declared
+		hostc.setJoinKeys(hostKeys, guestKeys)
+		return
+	case RelationshipManyToMany:
+		switch {
+		case len(host.PrimaryKeys) == 0 || len(guest.PrimaryKeys) == 0:
+			ignore("is declared many-to-many but %s or %s has no primary key", host.Name, guest.Name)
+		case hostc.JoinFK != "" && len(host.PrimaryKeys) > 1:
+			ignore("has join-fk:%s but %s has more than one primary key", hostc.JoinFK, host.Name)
+		case hostc.JoinRef != "" && len(guest.PrimaryKeys) > 1:
+			ignore("has join-ref:%s but %s has more than one primary key", hostc.JoinRef, guest.Name)
+		default:
+			hostc.Relationship = RelationshipManyToMany
+		}
+		return
+	}
+
+	if !isSlice {
+		// reanalyze if it's a valid belongs-to
+		guestKeys, hostKeys, belongsToFKs, ok := hostc.relationKeys(guest, host, hostc.Name)
+		if ok {
+			hostc.Relationship = RelationshipBelongsTo
+			hostc.setJoinKeys(hostKeys, guestKeys)
+			return
+		}
+
+		// reanalyze if it's a valid has-one
+		hostKeys, guestKeys, hasOneFKs, ok := hostc.relationKeys(host, guest, host.Name)
+		if !ok {
+			ignore("looks like belongs-to or has-one but %s has no %s and %s has no %s",
+				host.Name, strings.Join(belongsToFKs, " and "), guest.Name, strings.Join(hasOneFKs, " and "))
+			return
+		}
+		hostc.Relationship = RelationshipHasOne
+		hostc.setJoinKeys(hostKeys, guestKeys)
+		return
+	}
+
+	hostKeys, guestKeys, hasManyFKs, ok := hostc.relationKeys(host, guest, host.Name)
+	if !ok {
+		ignore("looks like has-many but %s has no %s", guest.Name, strings.Join(hasManyFKs, " and "))
+		return
+	}
+	hostc.setJoinKeys(hostKeys, guestKeys)
+	if explicit {
+		return
+	}
+
+	many2Many := len(guest.PrimaryKeys) > 0
+	for _, pk := range guest.PrimaryKeys {
+		many2Many = many2Many && host.HasColumn(guest.Name+pk.Name)
+	}
+	if many2Many {
+		hostc.Relationship = RelationshipManyToMany
+		hostc.joinKeys = nil
+	}
+}
+
+// ParseDirectives reads the directives of the parsed structs declared in
+// files.
+func (p *Parser) ParseDirectives(files []*ast.File) error {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				tspec := spec.(*ast.TypeSpec)
+				table, ok := p.Tables[tspec.Name.Name]
+				if !ok {
 					continue
 				}
-
-				many2Many := true
-				for _, pk := range guest.PrimaryKeys {
-					// TODO: custome primary key naming
-					many2Many = many2Many && host.HasColumn(guest.Name+pk.Name)
+				doc := tspec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
 				}
-				if many2Many {
-					hostc.Relationship = RelationshipManyToMany
+				if doc == nil {
+					continue
+				}
+				for _, comment := range doc.List {
+					if !strings.HasPrefix(comment.Text, DirectivePrefix) {
+						continue
+					}
+					if err := table.parseDirective(strings.TrimPrefix(comment.Text, DirectivePrefix)); err != nil {
+						return fmt.Errorf("%s: %s", table.Name, err)
+					}
 				}
 			}
 		}
 	}
-	return
+	return nil
+}
+
+func (t *Table) parseDirective(directive string) error {
+	name, args := directive, ""
+	if i := strings.IndexAny(directive, " \t"); i >= 0 {
+		name, args = directive[:i], strings.TrimSpace(directive[i+1:])
+	}
+
+	switch name {
+	case "funcs":
+		t.Option.Functions = nil
+		for _, f := range strings.Split(args, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				t.Option.Functions = append(t.Option.Functions, f)
+			}
+		}
+		if len(t.Option.Functions) == 0 {
+			return fmt.Errorf("no functions specified in go2sql:funcs")
+		}
+	default:
+		return fmt.Errorf("unknown directive go2sql:%s", name)
+	}
+	return nil
+}
+
+func isKnownFlag(flag string) bool {
+	switch {
+	case flag == "", flag == FlagID, flag == FlagPK, flag == FlagInline, flag == FlagJSON:
+		return true
+	}
+	for _, r := range []Relationship{RelationshipBelongsTo, RelationshipHasOne, RelationshipHasMany, RelationshipManyToMany} {
+		if flag == r.String() {
+			return true
+		}
+	}
+	for _, prefix := range []string{FlagPrefix, FlagForeignKey, FlagReferences, FlagJoinTable, FlagJoinFK, FlagJoinRef} {
+		if strings.HasPrefix(flag, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTimeType reports whether typ is time.Time, which is supported by the sql
+// drivers.
+func isTimeType(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time"
+}
+
+// isNullType reports whether typ is one of the sql.Null* types, e.g.
+// sql.NullString.
+func isNullType(typ types.Type) bool {
+	field, _ := nullValueField(typ)
+	return field != ""
+}
+
+// isSupportedType reports whether a column of typ can be scanned and written
+// by database/sql.
+func isSupportedType(typ types.Type) bool {
+	switch utyp := typ.Underlying().(type) {
+	case *types.Map, *types.Chan, *types.Signature:
+		return false
+	case *types.Pointer:
+		return isSupportedType(utyp.Elem())
+	case *types.Slice:
+		basic, ok := utyp.Elem().Underlying().(*types.Basic)
+		return ok && basic.Kind() == types.Byte
+	}
+	return true
+}
+
+// isArrayType reports whether typ is a slice of strings, booleans or numbers,
+// which is stored as an array column.
+func isArrayType(typ types.Type) bool {
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	basic, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && basic.Kind() != types.Byte && basic.Info()&(types.IsString|types.IsBoolean|types.IsInteger|types.IsFloat) != 0
+}
+
+// isJSONType reports whether values of typ can be encoded by encoding/json.
+func isJSONType(typ types.Type) bool {
+	switch utyp := typ.Underlying().(type) {
+	case *types.Chan, *types.Signature:
+		return false
+	case *types.Pointer:
+		return isJSONType(utyp.Elem())
+	}
+	return true
 }
 
 func (p *Parser) Qualifier(pkg *types.Package) string {
@@ -167,6 +640,9 @@ func (p *Parser) IsTable(typ types.Type) (bool, string, Relationship) {
 	// log.Printf("--> %s %T\n", types.TypeString(typ, p.Qualifier), typ)
 	switch utyp := typ.(type) {
 	case *types.Named:
+		if isTimeType(utyp) || isNullType(utyp) {
+			return false, "", 0
+		}
 		is, table, rel := p.IsTable(utyp.Underlying())
 		if _, ok := utyp.Underlying().(*types.Pointer); !ok {
 			table = types.TypeString(utyp, p.Qualifier)
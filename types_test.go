@@ -2,7 +2,6 @@ package main
 
 import (
 	"flag"
-	"fmt"
 	"go/ast"
 	"go/format"
 	"go/importer"
@@ -18,7 +17,6 @@ var funcsf string
 
 func init() {
 	flag.StringVar(&funcsf, "funcs", "", "func flags")
-	flag.Parse()
 }
 
 func TestVisitor(t *testing.T) {
@@ -132,7 +130,7 @@ type TeacherLanguageXref struct {
 
 	var conf types.Config
 	// log.Println(f.Name.Name)
-	conf.Importer = importer.For("gc", nil)
+	conf.Importer = importer.ForCompiler(fset, "source", nil)
 	pkg, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, &info)
 	if err != nil {
 		panic(err)
@@ -195,7 +193,7 @@ type TeacherLanguageXref struct {
 	}
 	for _, name := range funcs {
 		if err := tmpl.ExecuteTemplate(&language.w, name, language); err != nil {
-			printWithLineNo(rawTmpl)
+			printWithLineNo(language.w.String())
 			t.Fatal(err)
 		}
 	}
@@ -208,10 +206,3 @@ type TeacherLanguageXref struct {
 	printWithLineNo(string(src))
 	// printutils.PrettyPrint(language.Columns)
 }
-
-func printWithLineNo(src string) {
-	codes := strings.Split(src, "\n")
-	for i, line := range codes {
-		fmt.Printf("%3d: %s\n", i+1, line)
-	}
-}